@@ -0,0 +1,291 @@
+// Package activities provides the catalog of economic activity codes used by the
+// Servicio de Impuestos Internos (SII) of Chile.
+//
+// The SII classification (in force since 2018) is based on CIIU4.CL and is organized in
+// sections (a letter), divisions (2 digits), groups (3 digits) and activities (6 digits).
+//
+// The embedded catalog.csv is generated by internal/genactivities from the list of activity
+// codes published by the SII, with their VAT and tax category, exported as CSV:
+//
+//	go run ./internal/genactivities -activities actividades.csv -legacy correspondencia.csv
+//
+// The sections, divisions and groups are derived from the codes of the activities. The
+// copy checked in only covers part of the activities: the list of the SII is not in the
+// repository yet. A newer list can also be loaded at run time with ParseCatalog and
+// installed with SetDefault.
+package activities
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+//go:embed catalog.csv
+var catalogCSV string
+
+var ErrInvalidCatalog = errors.New("invalid activities catalog")
+
+type Level string
+
+const (
+	LevelSection  Level = "section"
+	LevelDivision Level = "division"
+	LevelGroup    Level = "group"
+	LevelActivity Level = "activity"
+)
+
+// VAT tells if the activity is subject to the value added tax (IVA).
+type VAT string
+
+const (
+	VATUnknown VAT = ""
+	VATYes     VAT = "SI"
+	VATNo      VAT = "NO"
+	// VATDepends is used by the SII ("G") when it depends on the operation.
+	VATDepends VAT = "G"
+)
+
+type Activity struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Level       Level  `json:"level"`
+	Section     string `json:"section,omitempty"`
+	Division    string `json:"division,omitempty"`
+	Group       string `json:"group,omitempty"`
+	VAT         VAT    `json:"vat,omitempty"`
+	// Category is the income tax category (1 or 2). 0 means unknown.
+	Category int `json:"category,omitempty"`
+}
+
+// Catalog is an in-memory index of activities by code.
+type Catalog struct {
+	byCode     map[string]Activity
+	normalized map[string]string
+	codes      []string
+}
+
+var (
+	defaultCatalog *Catalog
+	defaultMutex   sync.RWMutex
+	defaultOnce    sync.Once
+)
+
+// divisionSections maps the first division of each section to the section letter.
+var divisionSections = []struct {
+	firstDivision int
+	section       string
+}{
+	{1, "A"}, {5, "B"}, {10, "C"}, {35, "D"}, {36, "E"}, {41, "F"}, {45, "G"},
+	{49, "H"}, {55, "I"}, {58, "J"}, {64, "K"}, {68, "L"}, {69, "M"}, {77, "N"},
+	{84, "O"}, {85, "P"}, {86, "Q"}, {90, "R"}, {94, "S"}, {97, "T"}, {99, "U"},
+}
+
+// Default returns the catalog used by the package level functions.
+func Default() *Catalog {
+	defaultOnce.Do(func() {
+		c, err := ParseCatalog(strings.NewReader(catalogCSV))
+		if err != nil {
+			panic(err)
+		}
+		defaultMutex.Lock()
+		defaultCatalog = c
+		defaultMutex.Unlock()
+	})
+	defaultMutex.RLock()
+	defer defaultMutex.RUnlock()
+	return defaultCatalog
+}
+
+// SetDefault replaces the catalog used by the package level functions,
+// e.g. with the complete list published by the SII.
+func SetDefault(c *Catalog) {
+	defaultOnce.Do(func() {})
+	defaultMutex.Lock()
+	defaultCatalog = c
+	defaultMutex.Unlock()
+}
+
+// Lookup returns the activity (or section, division or group) with the given code.
+func Lookup(code string) (Activity, bool) {
+	return Default().Lookup(code)
+}
+
+// Search returns the activities whose description contains every word of text.
+// The comparison ignores case and accents.
+func Search(text string) []Activity {
+	return Default().Search(text)
+}
+
+// Parent returns the entry one level above the code in the hierarchy:
+// activity -> group -> division -> section.
+func Parent(code string) (Activity, bool) {
+	return Default().Parent(code)
+}
+
+// ParseCatalog reads a catalog in the format of the embedded catalog.csv: a header
+// line and one "code|description|vat|category" line per entry.
+func ParseCatalog(r io.Reader) (*Catalog, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = '|'
+	reader.FieldsPerRecord = 4
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Join(ErrInvalidCatalog, err)
+	}
+	c := &Catalog{byCode: map[string]Activity{}, normalized: map[string]string{}}
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "code") {
+			continue
+		}
+		activity, err := parseRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := c.byCode[activity.Code]; !ok {
+			c.codes = append(c.codes, activity.Code)
+		}
+		c.byCode[activity.Code] = activity
+		c.normalized[activity.Code] = pkg.NormalizeText(activity.Description)
+	}
+	sort.Strings(c.codes)
+	return c, nil
+}
+
+// Lookup returns the entry with the given code.
+func (c *Catalog) Lookup(code string) (Activity, bool) {
+	a, ok := c.byCode[strings.TrimSpace(code)]
+	return a, ok
+}
+
+// Search returns the activities whose description contains every word of text,
+// sorted by code. Sections, divisions and groups are not included.
+func (c *Catalog) Search(text string) []Activity {
+	words := strings.Fields(pkg.NormalizeText(text))
+	if len(words) == 0 {
+		return nil
+	}
+	var result []Activity
+	for _, code := range c.codes {
+		a := c.byCode[code]
+		if a.Level != LevelActivity {
+			continue
+		}
+		if containsAll(c.normalized[code], words) {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// Parent returns the entry one level above the code. When the parent is not in the
+// catalog, the returned entry has the code and level but an empty description.
+func (c *Catalog) Parent(code string) (Activity, bool) {
+	a, ok := c.Lookup(code)
+	if !ok {
+		level, err := levelOf(code)
+		if err != nil {
+			return Activity{}, false
+		}
+		a = newActivity(code, level)
+	}
+	var parentCode string
+	switch a.Level {
+	case LevelActivity:
+		parentCode = a.Group
+	case LevelGroup:
+		parentCode = a.Division
+	case LevelDivision:
+		parentCode = a.Section
+	default:
+		return Activity{}, false
+	}
+	if parent, ok := c.Lookup(parentCode); ok {
+		return parent, true
+	}
+	level, err := levelOf(parentCode)
+	if err != nil {
+		return Activity{}, false
+	}
+	return newActivity(parentCode, level), true
+}
+
+func parseRecord(record []string) (Activity, error) {
+	code := strings.TrimSpace(record[0])
+	level, err := levelOf(code)
+	if err != nil {
+		return Activity{}, err
+	}
+	a := newActivity(code, level)
+	a.Description = strings.TrimSpace(record[1])
+	a.VAT = VAT(strings.ToUpper(strings.TrimSpace(record[2])))
+	if category := strings.TrimSpace(record[3]); category != "" {
+		a.Category, err = strconv.Atoi(category)
+		if err != nil {
+			return Activity{}, errors.Join(ErrInvalidCatalog, err)
+		}
+	}
+	return a, nil
+}
+
+func newActivity(code string, level Level) Activity {
+	a := Activity{Code: code, Level: level}
+	if level == LevelSection {
+		return a
+	}
+	a.Division = code[:2]
+	a.Section = sectionOf(a.Division)
+	if level == LevelActivity {
+		a.Group = code[:3]
+	}
+	return a
+}
+
+func levelOf(code string) (Level, error) {
+	if len(code) == 1 && code[0] >= 'A' && code[0] <= 'Z' {
+		return LevelSection, nil
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidCatalog
+		}
+	}
+	switch len(code) {
+	case 2:
+		return LevelDivision, nil
+	case 3:
+		return LevelGroup, nil
+	case 6:
+		return LevelActivity, nil
+	}
+	return "", ErrInvalidCatalog
+}
+
+func sectionOf(division string) string {
+	d, _ := strconv.Atoi(division)
+	section := ""
+	for _, ds := range divisionSections {
+		if d < ds.firstDivision {
+			break
+		}
+		section = ds.section
+	}
+	return section
+}
+
+func containsAll(s string, words []string) bool {
+	for _, w := range words {
+		if !strings.Contains(s, w) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package activities
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	a, ok := Lookup("829900")
+	if !ok {
+		t.Fatalf("Lookup() not found")
+	}
+	if a.Description != "OTRAS ACTIVIDADES DE SERVICIOS DE APOYO A LAS EMPRESAS N.C.P." {
+		t.Errorf("Lookup() description = %q", a.Description)
+	}
+	if a.Level != LevelActivity || a.Section != "N" || a.Division != "82" || a.Group != "829" {
+		t.Errorf("Lookup() hierarchy = %+v", a)
+	}
+	if _, ok := Lookup("000000"); ok {
+		t.Errorf("Lookup() found an unknown code")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	got := Search("programación informática")
+	if len(got) != 1 || got[0].Code != "620100" {
+		t.Errorf("Search() = %+v", got)
+	}
+	if got := Search("   "); got != nil {
+		t.Errorf("Search() with empty text = %+v", got)
+	}
+}
+
+func TestParent(t *testing.T) {
+	chain := []string{"829900", "829", "82", "N"}
+	for i := 0; i < len(chain)-1; i++ {
+		parent, ok := Parent(chain[i])
+		if !ok || parent.Code != chain[i+1] {
+			t.Errorf("Parent(%q) = %+v, %v; want %q", chain[i], parent, ok, chain[i+1])
+		}
+	}
+	if _, ok := Parent("N"); ok {
+		t.Errorf("Parent() of a section should not exist")
+	}
+	// the parent of a code missing from the catalog is still resolved by its digits
+	parent, ok := Parent("011199")
+	if !ok || parent.Code != "011" || parent.Description == "" {
+		t.Errorf("Parent() of unknown code = %+v, %v", parent, ok)
+	}
+}
+
+func TestParseCatalog(t *testing.T) {
+	c, err := ParseCatalog(strings.NewReader("code|description|vat|category\n999999|X|SI|2\n"))
+	if err != nil {
+		t.Fatalf("ParseCatalog() error = %v", err)
+	}
+	a, ok := c.Lookup("999999")
+	if !ok || a.VAT != VATYes || a.Category != 2 || a.Section != "U" {
+		t.Errorf("ParseCatalog() entry = %+v", a)
+	}
+	if _, err := ParseCatalog(strings.NewReader("12345|X||\n")); err == nil {
+		t.Errorf("ParseCatalog() accepted an invalid code")
+	}
+}
+
+func TestMapLegacy(t *testing.T) {
+	got := MapLegacy("722000")
+	if len(got) != 2 || got[0].Code != "620100" || got[1].Code != "620200" || got[0].Description == "" {
+		t.Errorf("MapLegacy() = %+v", got)
+	}
+	if got := MapLegacy("999999"); len(got) != 0 {
+		t.Errorf("MapLegacy() of unknown code = %+v", got)
+	}
+	legacy := MapToLegacy("829900")
+	if len(legacy) != 1 || legacy[0].Code != "749990" {
+		t.Errorf("MapToLegacy() = %+v", legacy)
+	}
+}
+
+func TestDefaultLegacyTable_Codes(t *testing.T) {
+	codes := DefaultLegacyTable().Codes()
+	if !sort.StringsAreSorted(codes) {
+		t.Errorf("Codes() = %v, not sorted", codes)
+	}
+	for _, code := range codes {
+		if _, ok := Lookup(code); !ok {
+			t.Errorf("the legacy table maps to %s, which is not in the catalog", code)
+		}
+	}
+}
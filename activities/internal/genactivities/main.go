@@ -0,0 +1,498 @@
+// Command genactivities generates the embedded catalog.csv and legacy.csv of package
+// activities from the list of economic activity codes published by the SII ("Códigos de
+// Actividad Económica") and its table of correspondence with the codes used until 2018,
+// exported as CSV from the spreadsheets of the SII:
+//
+//	go run ./internal/genactivities -activities actividades.csv -legacy correspondencia.csv
+//
+// The exports may be separated by commas, semicolons or tabs and encoded in UTF-8 or
+// ISO-8859-1. The columns are found by their headers:
+//
+//   - activities: the code ("Código"), the description ("Descripción" or "Glosa"), the VAT
+//     ("Afecta IVA") and the tax category ("Categoría").
+//   - correspondence: the legacy code ("Código ... antiguo" or "anterior"), its description
+//     and the current code ("Código ... nuevo", "actual" or "vigente").
+//
+// Rows without a code, such as the titles of the sections, are skipped. In the
+// correspondence, a row without a legacy code continues the legacy code of the row above,
+// since the spreadsheet merges the cells of the codes that map to several current ones.
+//
+// The sections, divisions and groups are derived from the codes of the activities. Their
+// descriptions are taken from the rows of the list that have their code (e.g. "A" or "829")
+// and otherwise from the current catalog.csv.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Eitol/gosii/activities"
+	"github.com/Eitol/gosii/pkg"
+)
+
+func main() {
+	source := flag.String("activities", "", "CSV export of the activity codes of the SII")
+	legacy := flag.String("legacy", "", "CSV export of the correspondence with the legacy codes")
+	out := flag.String("out", ".", "directory of catalog.csv and legacy.csv")
+	flag.Parse()
+	if *source == "" && *legacy == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *source != "" {
+		path := filepath.Join(*out, "catalog.csv")
+		n, err := generateCatalog(*source, path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s: %d activities\n", path, n)
+	}
+	if *legacy != "" {
+		path := filepath.Join(*out, "legacy.csv")
+		n, err := generateLegacy(*legacy, path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s: %d correspondences\n", path, n)
+		missing, err := missingCodes(path, filepath.Join(*out, "catalog.csv"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(missing) > 0 {
+			fmt.Printf("%s: %d current codes are not in the catalog: %s\n", path, len(missing), strings.Join(missing, " "))
+		}
+	}
+}
+
+// generateCatalog replaces the catalog at path with the activities of source and their
+// sections, divisions and groups, and returns the number of activities.
+func generateCatalog(source, path string) (int, error) {
+	rows, err := readTable(source)
+	if err != nil {
+		return 0, err
+	}
+	records, titles, err := catalogRecords(rows)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", source, err)
+	}
+	current, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+	descriptions, err := catalogDescriptions(current)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+	for code, d := range titles {
+		descriptions[code] = d
+	}
+	hierarchy, err := hierarchyRecords(records, descriptions)
+	if err != nil {
+		return 0, err
+	}
+	data, err := writeTable([]string{"code", "description", "vat", "category"}, append(hierarchy, records...))
+	if err != nil {
+		return 0, err
+	}
+	if _, err := activities.ParseCatalog(bytes.NewReader(data)); err != nil {
+		return 0, err
+	}
+	return len(records), writeFile(path, data)
+}
+
+// catalogRecords converts the rows of the list of the SII to catalog records, sorted by code.
+// It also returns the descriptions of the rows of the sections, divisions and groups.
+func catalogRecords(rows [][]string) ([][]string, map[string]string, error) {
+	header, columns := findHeader(rows, []column{
+		{"code", []string{"CODIGO"}, nil},
+		{"description", []string{"DESCRIPCION", "GLOSA"}, nil},
+		{"vat", []string{"IVA"}, nil},
+		{"category", []string{"CATEGORIA"}, nil},
+	})
+	if header < 0 || columns["code"] < 0 || columns["description"] < 0 {
+		return nil, nil, errors.New("no code and description columns")
+	}
+	byCode := map[string][]string{}
+	titles := map[string]string{}
+	for _, row := range rows[header+1:] {
+		if code := hierarchyCode(cell(row, columns["code"])); code != "" {
+			if d := description(cell(row, columns["description"])); d != "" {
+				titles[code] = d
+			}
+			continue
+		}
+		code := activityCode(cell(row, columns["code"]))
+		if code == "" {
+			continue
+		}
+		category, err := taxCategory(cell(row, columns["category"]))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", code, err)
+		}
+		byCode[code] = []string{code, description(cell(row, columns["description"])), vat(cell(row, columns["vat"])), category}
+	}
+	if len(byCode) == 0 {
+		return nil, nil, errors.New("no activities")
+	}
+	records := make([][]string, 0, len(byCode))
+	for _, record := range byCode {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(a, b int) bool { return records[a][0] < records[b][0] })
+	return records, titles, nil
+}
+
+// generateLegacy replaces the legacy table at path with the correspondence of source, and
+// returns the number of correspondences.
+func generateLegacy(source, path string) (int, error) {
+	rows, err := readTable(source)
+	if err != nil {
+		return 0, err
+	}
+	records, err := legacyRecords(rows)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", source, err)
+	}
+	data, err := writeTable([]string{"legacy_code", "legacy_description", "code"}, records)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := activities.ParseLegacyTable(bytes.NewReader(data)); err != nil {
+		return 0, err
+	}
+	return len(records), writeFile(path, data)
+}
+
+// legacyRecords converts the rows of the correspondence of the SII to legacy table records,
+// sorted by legacy code and current code.
+func legacyRecords(rows [][]string) ([][]string, error) {
+	header, columns := findHeader(rows, []column{
+		{"legacy_code", []string{"CODIGO"}, []string{"ANTIGU", "ANTERIOR"}},
+		{"code", []string{"CODIGO"}, []string{"NUEV", "ACTUAL", "VIGENTE"}},
+		{"legacy_description", []string{"DESCRIPCION", "GLOSA"}, []string{"ANTIGU", "ANTERIOR"}},
+	})
+	if header < 0 || columns["legacy_code"] < 0 || columns["code"] < 0 {
+		return nil, errors.New("no legacy code and current code columns")
+	}
+	if columns["legacy_description"] < 0 {
+		// without a qualified header, the description is the column after the legacy code
+		if j := columns["legacy_code"] + 1; j != columns["code"] && strings.TrimSpace(cell(rows[header], j)) != "" {
+			columns["legacy_description"] = j
+		}
+	}
+	var records [][]string
+	seen := map[[2]string]bool{}
+	var legacyCode, legacyDescription string
+	for _, row := range rows[header+1:] {
+		if c := strings.TrimSpace(cell(row, columns["legacy_code"])); c != "" {
+			legacyCode = activityCode(c)
+			legacyDescription = description(cell(row, columns["legacy_description"]))
+		}
+		code := activityCode(cell(row, columns["code"]))
+		if legacyCode == "" || code == "" || seen[[2]string{legacyCode, code}] {
+			continue
+		}
+		seen[[2]string{legacyCode, code}] = true
+		records = append(records, []string{legacyCode, legacyDescription, code})
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no correspondences")
+	}
+	sort.Slice(records, func(a, b int) bool {
+		if records[a][0] != records[b][0] {
+			return records[a][0] < records[b][0]
+		}
+		return records[a][2] < records[b][2]
+	})
+	return records, nil
+}
+
+// missingCodes returns the current codes of the legacy table at path that are not in the
+// catalog at catalogPath, so that the two files can be checked against each other.
+func missingCodes(path, catalogPath string) ([]string, error) {
+	legacyFile, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer legacyFile.Close()
+	table, err := activities.ParseLegacyTable(legacyFile)
+	if err != nil {
+		return nil, err
+	}
+	catalogFile, err := os.Open(catalogPath)
+	if err != nil {
+		return nil, err
+	}
+	defer catalogFile.Close()
+	catalog, err := activities.ParseCatalog(catalogFile)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, code := range table.Codes() {
+		if _, ok := catalog.Lookup(code); !ok {
+			missing = append(missing, code)
+		}
+	}
+	return missing, nil
+}
+
+// hierarchyRecords returns the sections, divisions and groups of the activities, with their
+// descriptions (empty when unknown), sorted by level and code.
+func hierarchyRecords(records [][]string, descriptions map[string]string) ([][]string, error) {
+	data, err := writeTable([]string{"code", "description", "vat", "category"}, records)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := activities.ParseCatalog(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	levels := make([]map[string]bool, 3)
+	for i := range levels {
+		levels[i] = map[string]bool{}
+	}
+	for _, record := range records {
+		a, _ := catalog.Lookup(record[0])
+		levels[0][a.Section] = true
+		levels[1][a.Division] = true
+		levels[2][a.Group] = true
+	}
+	var hierarchy [][]string
+	for _, codes := range levels {
+		sorted := make([]string, 0, len(codes))
+		for code := range codes {
+			sorted = append(sorted, code)
+		}
+		sort.Strings(sorted)
+		for _, code := range sorted {
+			hierarchy = append(hierarchy, []string{code, descriptions[code], "", ""})
+		}
+	}
+	return hierarchy, nil
+}
+
+// catalogDescriptions returns the descriptions of the entries of a catalog.csv by code.
+func catalogDescriptions(data []byte) (map[string]string, error) {
+	descriptions := map[string]string{}
+	if len(data) == 0 {
+		return descriptions, nil
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = '|'
+	reader.FieldsPerRecord = 4
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "code") {
+			continue
+		}
+		descriptions[record[0]] = record[1]
+	}
+	return descriptions, nil
+}
+
+// hierarchyCode returns the code of a cell of a section ("A" or "Sección A"), division
+// (2 digits) or group (3 digits), or "" if it is not one.
+func hierarchyCode(s string) string {
+	s = strings.TrimSpace(strings.TrimPrefix(pkg.NormalizeText(s), "SECCION"))
+	if len(s) == 1 && s[0] >= 'A' && s[0] <= 'U' {
+		return s
+	}
+	if len(s) < 2 || len(s) > 3 {
+		return ""
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return s
+}
+
+// activityCode returns the 6-digit code of a cell, or "" if it is not an activity code.
+// The spreadsheets drop the leading zero of the codes of the first divisions.
+func activityCode(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) < 5 || len(s) > 6 {
+		return ""
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return strings.Repeat("0", 6-len(s)) + s
+}
+
+func description(s string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(s)), " ")
+}
+
+// vat converts the "Afecta IVA" column: "SI", "NO" or "G" (depends on the operation).
+func vat(s string) string {
+	switch v := pkg.NormalizeText(s); v {
+	case "SI", "S":
+		return string(activities.VATYes)
+	case "NO", "N":
+		return string(activities.VATNo)
+	case "G":
+		return string(activities.VATDepends)
+	default:
+		return string(activities.VATUnknown)
+	}
+}
+
+// taxCategory converts the "Categoría" column: 1 or 2, and "" when it does not apply (ND).
+func taxCategory(s string) (string, error) {
+	switch c := pkg.NormalizeText(s); c {
+	case "1", "PRIMERA", "1RA":
+		return "1", nil
+	case "2", "SEGUNDA", "2DA":
+		return "2", nil
+	case "", "ND", "NA", "-":
+		return "", nil
+	default:
+		return "", fmt.Errorf("unknown tax category %q", s)
+	}
+}
+
+// column is a column of an export, found by the words of its header.
+type column struct {
+	name  string
+	words []string
+	// qualifiers, when set, must also appear in the header, to tell apart the columns with
+	// the same words (the legacy and the current code).
+	qualifiers []string
+}
+
+// findHeader returns the index of the first row that has at least two of columns, and the
+// index of each column in it (-1 when missing). A column matches when its normalized header
+// contains one of the words and one of the qualifiers; the columns are matched in order,
+// each to a different cell.
+func findHeader(rows [][]string, columns []column) (int, map[string]int) {
+	for i, row := range rows {
+		found := map[string]int{}
+		matched := 0
+		for _, col := range columns {
+			found[col.name] = -1
+			for j, c := range row {
+				h := pkg.NormalizeText(c)
+				if containsAny(h, col.words) && (col.qualifiers == nil || containsAny(h, col.qualifiers)) && !isTaken(found, j) {
+					found[col.name] = j
+					matched++
+					break
+				}
+			}
+		}
+		if matched >= 2 {
+			return i, found
+		}
+	}
+	return -1, nil
+}
+
+func containsAny(s string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTaken(found map[string]int, column int) bool {
+	for _, j := range found {
+		if j == column {
+			return true
+		}
+	}
+	return false
+}
+
+func cell(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return row[i]
+}
+
+// readTable reads a CSV export in UTF-8 or ISO-8859-1, separated by commas, semicolons or tabs.
+func readTable(path string) ([][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		data = latin1ToUTF8(data)
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = separator(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	return reader.ReadAll()
+}
+
+// separator guesses the separator of a CSV export from the header line (the first line with
+// "Código" and a separator, skipping the titles), since the descriptions can have commas.
+func separator(data []byte) rune {
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if !strings.Contains(pkg.NormalizeText(string(line)), "CODIGO") {
+			continue
+		}
+		best, count := ',', 0
+		for _, sep := range []rune{',', ';', '\t'} {
+			if n := bytes.Count(line, []byte(string(sep))); n > count {
+				best, count = sep, n
+			}
+		}
+		if count > 0 {
+			return best
+		}
+	}
+	return ','
+}
+
+func latin1ToUTF8(data []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(data) + len(data)/8)
+	for _, b := range data {
+		buf.WriteRune(rune(b))
+	}
+	return buf.Bytes()
+}
+
+func writeTable(header []string, records [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = '|'
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFile replaces the file at path, so that it is never left half written.
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Eitol/gosii/activities"
+)
+
+func TestGenerateCatalog(t *testing.T) {
+	dir := t.TempDir()
+	// an export of the spreadsheet of the SII: ISO-8859-1, semicolons, titles between the rows
+	source := filepath.Join(dir, "actividades.csv")
+	export := "LISTADO DE C\xd3DIGOS\n\n" +
+		"C\xf3digo;Descripci\xf3n;Afecta IVA;Categor\xeda Tributaria;Disponible Internet\n" +
+		"AGRICULTURA, GANADER\xcdA, SILVICULTURA Y PESCA;;;;\n" +
+		"11101;Cultivo de trigo;SI;1;SI\n" +
+		"829;Otras actividades de servicios de apoyo a las empresas;;;\n" +
+		"829900;OTRAS ACTIVIDADES DE SERVICIOS DE APOYO A LAS EMPRESAS N.C.P.;S\xcd;1;SI\n" +
+		"960909;OTRAS ACTIVIDADES DE SERVICIOS PERSONALES N.C.P.;G;2;SI\n" +
+		"990000;ACTIVIDADES DE ORGANIZACIONES Y \xd3RGANOS EXTRATERRITORIALES;NO;ND;NO\n"
+	if err := os.WriteFile(source, []byte(export), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "catalog.csv")
+	current := "code|description|vat|category\nA|AGRICULTURA||\n01|CULTIVOS||\n011|CULTIVO DE PLANTAS NO PERENNES||\n012|CULTIVO DE PLANTAS PERENNES||\n011101|OLD||\n"
+	if err := os.WriteFile(path, []byte(current), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := generateCatalog(source, path)
+	if err != nil || n != 4 {
+		t.Fatalf("generateCatalog() = %d, %v", n, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := activities.ParseCatalog(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]activities.Activity{
+		"011101": {Code: "011101", Description: "CULTIVO DE TRIGO", Level: activities.LevelActivity, Section: "A", Division: "01", Group: "011", VAT: activities.VATYes, Category: 1},
+		"960909": {Code: "960909", Description: "OTRAS ACTIVIDADES DE SERVICIOS PERSONALES N.C.P.", Level: activities.LevelActivity, Section: "S", Division: "96", Group: "960", VAT: activities.VATDepends, Category: 2},
+		"990000": {Code: "990000", Description: "ACTIVIDADES DE ORGANIZACIONES Y ÓRGANOS EXTRATERRITORIALES", Level: activities.LevelActivity, Section: "U", Division: "99", Group: "990", VAT: activities.VATNo},
+	}
+	for code, a := range want {
+		if got, ok := catalog.Lookup(code); !ok || !reflect.DeepEqual(got, a) {
+			t.Errorf("Lookup(%s) = %+v, want %+v", code, got, a)
+		}
+	}
+	// the hierarchy comes from the codes, with the descriptions of the list or of the current catalog
+	hierarchy := map[string]string{
+		"A":   "AGRICULTURA",
+		"S":   "",
+		"01":  "CULTIVOS",
+		"82":  "",
+		"011": "CULTIVO DE PLANTAS NO PERENNES",
+		"829": "OTRAS ACTIVIDADES DE SERVICIOS DE APOYO A LAS EMPRESAS",
+		"990": "",
+	}
+	for code, d := range hierarchy {
+		if a, ok := catalog.Lookup(code); !ok || a.Description != d {
+			t.Errorf("Lookup(%s) = %+v, %v, want the description %q", code, a, ok, d)
+		}
+	}
+	if a, ok := catalog.Lookup("012"); ok {
+		t.Errorf("the group 012 has no activities but was kept: %+v", a)
+	}
+}
+
+func TestGenerateLegacy(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "correspondencia.csv")
+	// the cells of the legacy codes that map to several current ones are merged
+	export := "Tabla de correspondencia\n" +
+		"C\xf3digo actividad antigua,Glosa actividad antigua,C\xf3digo actividad nueva,Glosa actividad nueva\n" +
+		"452010,\"CONSTRUCCION DE EDIFICIOS COMPLETOS O DE PARTES DE EDIFICIOS\",410010,CONSTRUCCI\xd3N DE EDIFICIOS PARA USO RESIDENCIAL\n" +
+		",,410020,CONSTRUCCI\xd3N DE EDIFICIOS PARA USO NO RESIDENCIAL\n" +
+		"11111,Cultivo de trigo,11101,CULTIVO DE TRIGO\n" +
+		"11111,Cultivo de trigo,11101,CULTIVO DE TRIGO\n"
+	if err := os.WriteFile(source, []byte(export), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "legacy.csv")
+
+	n, err := generateLegacy(source, path)
+	if err != nil || n != 3 {
+		t.Fatalf("generateLegacy() = %d, %v", n, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "legacy_code|legacy_description|code\n" +
+		"011111|CULTIVO DE TRIGO|011101\n" +
+		"452010|CONSTRUCCION DE EDIFICIOS COMPLETOS O DE PARTES DE EDIFICIOS|410010\n" +
+		"452010|CONSTRUCCION DE EDIFICIOS COMPLETOS O DE PARTES DE EDIFICIOS|410020\n"
+	if string(data) != want {
+		t.Errorf("legacy.csv =\n%s\nwant\n%s", data, want)
+	}
+
+	catalog := filepath.Join(dir, "catalog.csv")
+	if err := os.WriteFile(catalog, []byte("code|description|vat|category\n011101|CULTIVO DE TRIGO|SI|1\n410010|CONSTRUCCION|SI|1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	missing, err := missingCodes(path, catalog)
+	if err != nil || !reflect.DeepEqual(missing, []string{"410020"}) {
+		t.Errorf("missingCodes() = %v, %v", missing, err)
+	}
+}
@@ -0,0 +1,152 @@
+package activities
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed legacy.csv
+var legacyCSV string
+
+var ErrInvalidLegacyTable = errors.New("invalid legacy activities table")
+
+// LegacyActivity is an activity code of the classification used by the SII until 2018.
+type LegacyActivity struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// LegacyTable is the correspondence between the legacy activity codes and the current ones.
+// A legacy code can map to several current codes and vice versa.
+type LegacyTable struct {
+	legacy    map[string]LegacyActivity
+	toCurrent map[string][]string
+	toLegacy  map[string][]string
+}
+
+var (
+	defaultLegacyTable *LegacyTable
+	defaultLegacyMutex sync.RWMutex
+	defaultLegacyOnce  sync.Once
+)
+
+// DefaultLegacyTable returns the table used by MapLegacy and MapToLegacy.
+//
+// The embedded legacy.csv is generated by internal/genactivities from the table of
+// correspondence published by the SII, exported as CSV. Like the catalog, the copy checked
+// in only covers part of the codes.
+func DefaultLegacyTable() *LegacyTable {
+	defaultLegacyOnce.Do(func() {
+		t, err := ParseLegacyTable(strings.NewReader(legacyCSV))
+		if err != nil {
+			panic(err)
+		}
+		defaultLegacyMutex.Lock()
+		defaultLegacyTable = t
+		defaultLegacyMutex.Unlock()
+	})
+	defaultLegacyMutex.RLock()
+	defer defaultLegacyMutex.RUnlock()
+	return defaultLegacyTable
+}
+
+// SetDefaultLegacyTable replaces the table used by MapLegacy and MapToLegacy.
+func SetDefaultLegacyTable(t *LegacyTable) {
+	defaultLegacyOnce.Do(func() {})
+	defaultLegacyMutex.Lock()
+	defaultLegacyTable = t
+	defaultLegacyMutex.Unlock()
+}
+
+// MapLegacy returns the current activities that correspond to a legacy activity code.
+//
+// The activities are taken from the default catalog; the ones missing from it are
+// returned with only their code and hierarchy.
+func MapLegacy(legacyCode string) []Activity {
+	return DefaultLegacyTable().MapLegacy(legacyCode, Default())
+}
+
+// MapToLegacy returns the legacy activities that correspond to a current activity code.
+func MapToLegacy(code string) []LegacyActivity {
+	return DefaultLegacyTable().MapToLegacy(code)
+}
+
+// ParseLegacyTable reads a table in the format of the embedded legacy.csv: a header line
+// and one "legacy_code|legacy_description|code" line per correspondence.
+func ParseLegacyTable(r io.Reader) (*LegacyTable, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = '|'
+	reader.FieldsPerRecord = 3
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Join(ErrInvalidLegacyTable, err)
+	}
+	t := &LegacyTable{
+		legacy:    map[string]LegacyActivity{},
+		toCurrent: map[string][]string{},
+		toLegacy:  map[string][]string{},
+	}
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(record[0], "legacy_code") {
+			continue
+		}
+		legacyCode := strings.TrimSpace(record[0])
+		code := strings.TrimSpace(record[2])
+		if level, err := levelOf(code); err != nil || level != LevelActivity || len(legacyCode) != 6 {
+			return nil, ErrInvalidLegacyTable
+		}
+		t.legacy[legacyCode] = LegacyActivity{Code: legacyCode, Description: strings.TrimSpace(record[1])}
+		t.toCurrent[legacyCode] = appendUnique(t.toCurrent[legacyCode], code)
+		t.toLegacy[code] = appendUnique(t.toLegacy[code], legacyCode)
+	}
+	return t, nil
+}
+
+// MapLegacy returns the activities of catalog that correspond to a legacy activity code.
+func (t *LegacyTable) MapLegacy(legacyCode string, catalog *Catalog) []Activity {
+	codes := t.toCurrent[strings.TrimSpace(legacyCode)]
+	result := make([]Activity, 0, len(codes))
+	for _, code := range codes {
+		a, ok := catalog.Lookup(code)
+		if !ok {
+			a = newActivity(code, LevelActivity)
+		}
+		result = append(result, a)
+	}
+	return result
+}
+
+// Codes returns the current activity codes of the table, sorted.
+func (t *LegacyTable) Codes() []string {
+	codes := make([]string, 0, len(t.toLegacy))
+	for code := range t.toLegacy {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// MapToLegacy returns the legacy activities that correspond to a current activity code.
+func (t *LegacyTable) MapToLegacy(code string) []LegacyActivity {
+	legacyCodes := t.toLegacy[strings.TrimSpace(code)]
+	result := make([]LegacyActivity, 0, len(legacyCodes))
+	for _, legacyCode := range legacyCodes {
+		result = append(result, t.legacy[legacyCode])
+	}
+	return result
+}
+
+func appendUnique(codes []string, code string) []string {
+	for _, c := range codes {
+		if c == code {
+			return codes
+		}
+	}
+	return append(codes, code)
+}
@@ -0,0 +1,106 @@
+package gosii
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+var ErrAudit = errors.New("audit log failed")
+var ErrMissingPurpose = errors.New("missing lookup purpose")
+
+type AuditOutcome string
+
+const (
+	AuditFound      AuditOutcome = "found"
+	AuditNotFound   AuditOutcome = "not_found"
+	AuditInvalidRUT AuditOutcome = "invalid_rut"
+	AuditError      AuditOutcome = "error"
+)
+
+// AuditPurposeRefresh is the purpose of the entries of the background refreshes of the
+// cache (see Opts.StaleWhileRevalidate), which are not made on behalf of a user.
+const AuditPurposeRefresh = "cache refresh"
+
+// AuditInfo is the justification of a lookup, supplied by the caller through the context.
+type AuditInfo struct {
+	Purpose string `json:"purpose"`
+	UserID  string `json:"user_id"`
+}
+
+// AuditEntry is the record of a lookup in the audit log.
+type AuditEntry struct {
+	Time    time.Time    `json:"time"`
+	Purpose string       `json:"purpose"`
+	UserID  string       `json:"user_id"`
+	Rut     string       `json:"rut"`
+	Outcome AuditOutcome `json:"outcome"`
+	Error   string       `json:"error,omitempty"`
+	// ResponseSHA256 is the hex SHA-256 of the raw response of the SII, if any.
+	ResponseSHA256 string `json:"response_sha256,omitempty"`
+	// PrevHash and Hash chain the entries, so that removing or editing an entry is
+	// detected. They are set by the AuditSink.
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// AuditSink receives an entry per lookup. Implementations must be safe for concurrent use
+// (see the audit package for an append-only file implementation).
+type AuditSink interface {
+	Record(entry AuditEntry) error
+}
+
+type auditInfoKey struct{}
+
+// WithAuditInfo returns a copy of ctx that carries the purpose and user of the lookups
+// made with it.
+//
+//	ctx := gosii.WithAuditInfo(ctx, gosii.AuditInfo{Purpose: "credit evaluation #123", UserID: "jdoe"})
+//	citizen, _, err := gosii.LookupContext(ctx, client, rut)
+func WithAuditInfo(ctx context.Context, info AuditInfo) context.Context {
+	return context.WithValue(ctx, auditInfoKey{}, info)
+}
+
+// AuditInfoFromContext returns the AuditInfo set with WithAuditInfo.
+func AuditInfoFromContext(ctx context.Context) (AuditInfo, bool) {
+	info, ok := ctx.Value(auditInfoKey{}).(AuditInfo)
+	return info, ok
+}
+
+func (c *SIIClient) audit(info AuditInfo, rut string, body []byte, lookupErr error) error {
+	entry := AuditEntry{
+		Time:    time.Now().UTC(),
+		Purpose: info.Purpose,
+		UserID:  info.UserID,
+		Rut:     rut,
+		Outcome: auditOutcome(lookupErr),
+	}
+	if lookupErr != nil {
+		entry.Error = lookupErr.Error()
+	}
+	if body != nil {
+		sum := sha256.Sum256(body)
+		entry.ResponseSHA256 = hex.EncodeToString(sum[:])
+	}
+	if err := c.opts.Audit.Record(entry); err != nil {
+		return errors.Join(ErrAudit, err)
+	}
+	return nil
+}
+
+func auditOutcome(err error) AuditOutcome {
+	switch {
+	case err == nil:
+		return AuditFound
+	case errors.Is(err, ErrNotFound):
+		return AuditNotFound
+	case errors.Is(err, pkg.ErrInvalidRUT) || errors.Is(err, pkg.ErrInvalidDV):
+		return AuditInvalidRUT
+	default:
+		return AuditError
+	}
+}
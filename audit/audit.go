@@ -0,0 +1,293 @@
+// Package audit writes the gosii audit log as append-only JSON lines and verifies it.
+//
+// Each entry carries the hash of the previous one (PrevHash) and its own hash (Hash), which
+// is the hex SHA-256 of the previous hash followed by the JSON of the entry without its hash.
+// Editing, removing or reordering entries breaks the chain, and Verify reports where.
+//
+// Removing the last entries keeps the chain intact, so FileLog also keeps a checkpoint next to
+// the log (the path of the log plus ".checkpoint") with the number of entries and the hash of
+// the last one. VerifyFile and OpenFile report a log shorter than its checkpoint, or one whose
+// entry at the checkpoint does not have its hash. Keep a copy of the checkpoint elsewhere to
+// detect a truncation made together with the checkpoint.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/Eitol/gosii"
+)
+
+var ErrTampered = errors.New("audit log tampered")
+
+// Checkpoint is the number of entries of an audit log and the hash of the last one.
+type Checkpoint struct {
+	Count int    `json:"count"`
+	Hash  string `json:"hash"`
+}
+
+// FileLog is a gosii.AuditSink that appends the entries to a file.
+type FileLog struct {
+	mutex sync.Mutex
+	f     *os.File
+	path  string
+	// size is the length of the file up to the last entry written.
+	size       int64
+	checkpoint Checkpoint
+	// err is set when a failed write could not be undone; no more entries are written.
+	err error
+}
+
+// OpenFile opens (or creates) the audit log at path. The existing entries are verified
+// before appending new ones; ErrTampered is returned if the chain is broken or the log is
+// shorter than its checkpoint. A last line left incomplete by a crash is removed.
+func OpenFile(path string) (*FileLog, error) {
+	cp, err := ReadCheckpoint(checkpointPath(path))
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	l, err := open(f, path, cp)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func open(f *os.File, path string, cp *Checkpoint) (*FileLog, error) {
+	res, err := verify(f, cp)
+	if err != nil {
+		return nil, err
+	}
+	l := &FileLog{f: f, path: path, size: res.size, checkpoint: Checkpoint{Count: res.count, Hash: res.lastHash}}
+	switch {
+	case res.partial > 0:
+		if err := f.Truncate(res.size); err != nil {
+			return nil, err
+		}
+	case res.unterminated:
+		// the last entry is complete but lost its newline
+		if _, err := f.Write([]byte{'\n'}); err != nil {
+			return nil, err
+		}
+		l.size++
+	}
+	if err := f.Sync(); err != nil {
+		return nil, err
+	}
+	if err := l.saveCheckpoint(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Record chains the entry to the previous one and appends it to the file, synced to disk.
+// If the write fails, the file is truncated back so that it does not keep a partial line.
+func (l *FileLog) Record(entry gosii.AuditEntry) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+	entry.Time = entry.Time.UTC()
+	entry.PrevHash = l.checkpoint.Hash
+	hash, err := Hash(entry)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	_, err = l.f.Write(line)
+	if err == nil {
+		err = l.f.Sync()
+	}
+	if err != nil {
+		if truncErr := l.f.Truncate(l.size); truncErr != nil {
+			l.err = fmt.Errorf("audit log left with a partial entry: %w", errors.Join(err, truncErr))
+			return l.err
+		}
+		return err
+	}
+	l.size += int64(len(line))
+	l.checkpoint = Checkpoint{Count: l.checkpoint.Count + 1, Hash: hash}
+	// the entry is recorded even if the checkpoint is not; the next one updates it
+	return l.saveCheckpoint()
+}
+
+func (l *FileLog) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.f.Close()
+}
+
+// saveCheckpoint replaces the checkpoint file, so that it is never left half written.
+func (l *FileLog) saveCheckpoint() error {
+	data, err := json.Marshal(l.checkpoint)
+	if err != nil {
+		return err
+	}
+	path := checkpointPath(l.path)
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func checkpointPath(path string) string {
+	return path + ".checkpoint"
+}
+
+// ReadCheckpoint reads a checkpoint file written by FileLog. It returns nil if the file
+// does not exist.
+func ReadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("%w: checkpoint: %v", ErrTampered, err)
+	}
+	return &cp, nil
+}
+
+// Hash returns the hash of the entry, computed from its PrevHash and the rest of its fields.
+func Hash(entry gosii.AuditEntry) (string, error) {
+	entry.Hash = ""
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(entry.PrevHash))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Verify checks the hash chain of an audit log and returns the number of entries.
+// The error wraps ErrTampered and tells the first line that does not match. A last line
+// cut in the middle of an entry (a write interrupted by a crash) is not counted.
+func Verify(r io.Reader) (int, error) {
+	res, err := verify(r, nil)
+	return res.count, err
+}
+
+// VerifyCheckpoint is like Verify, and also checks that the log has at least cp.Count
+// entries and that the hash of entry cp.Count is cp.Hash, so that removing the last
+// entries is detected.
+func VerifyCheckpoint(r io.Reader, cp Checkpoint) (int, error) {
+	res, err := verify(r, &cp)
+	return res.count, err
+}
+
+// VerifyFile verifies the audit log at path against its checkpoint, if it has one.
+func VerifyFile(path string) (int, error) {
+	cp, err := ReadCheckpoint(checkpointPath(path))
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	res, err := verify(f, cp)
+	return res.count, err
+}
+
+type verifyResult struct {
+	lastHash string
+	count    int
+	// size is the length of the log up to the end of the last entry.
+	size int64
+	// partial is the length of an incomplete last line; unterminated is set when the last
+	// entry is complete but has no newline.
+	partial      int64
+	unterminated bool
+}
+
+func verify(r io.Reader, cp *Checkpoint) (verifyResult, error) {
+	var res verifyResult
+	reader := bufio.NewReader(r)
+	line := 0
+	for {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return res, err
+		}
+		if len(data) == 0 {
+			break
+		}
+		line++
+		complete := data[len(data)-1] == '\n'
+		trimmed := bytes.TrimSpace(data)
+		if len(trimmed) == 0 {
+			res.size += int64(len(data))
+			continue
+		}
+		var entry gosii.AuditEntry
+		if jsonErr := json.Unmarshal(trimmed, &entry); jsonErr != nil {
+			if !complete {
+				res.partial = int64(len(data))
+				break
+			}
+			return res, fmt.Errorf("%w: line %d: %v", ErrTampered, line, jsonErr)
+		}
+		if entry.PrevHash != res.lastHash {
+			return res, fmt.Errorf("%w: line %d: previous hash does not match", ErrTampered, line)
+		}
+		hash, hashErr := Hash(entry)
+		if hashErr != nil {
+			return res, hashErr
+		}
+		if hash != entry.Hash {
+			return res, fmt.Errorf("%w: line %d: hash does not match", ErrTampered, line)
+		}
+		res.lastHash = hash
+		res.count++
+		res.size += int64(len(data))
+		res.unterminated = !complete
+		if cp != nil && res.count == cp.Count && hash != cp.Hash {
+			return res, fmt.Errorf("%w: line %d: hash does not match the checkpoint", ErrTampered, line)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if cp != nil && res.count < cp.Count {
+		return res, fmt.Errorf("%w: %d entries, the checkpoint has %d", ErrTampered, res.count, cp.Count)
+	}
+	return res, nil
+}
@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eitol/gosii"
+)
+
+func writeLog(t *testing.T, path string, ruts ...string) {
+	t.Helper()
+	l, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	defer l.Close()
+	for _, rut := range ruts {
+		entry := gosii.AuditEntry{Time: time.Now(), Purpose: "test", UserID: "u1", Rut: rut, Outcome: gosii.AuditFound}
+		if err := l.Record(entry); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+}
+
+func TestFileLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeLog(t, path, "41399675-9", "42533552-9")
+	// reopening continues the chain
+	writeLog(t, path, "42266856-K")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := Verify(bytes.NewReader(data))
+	if err != nil || count != 3 {
+		t.Fatalf("Verify() = %d, %v, want 3 entries", count, err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+		line   string
+	}{
+		{"edited", func(lines []string) []string {
+			lines[1] = strings.Replace(lines[1], "42533552-9", "41309908-0", 1)
+			return lines
+		}, "line 2"},
+		{"removed", func(lines []string) []string {
+			return append(lines[:1], lines[2:]...)
+		}, "line 2"},
+		{"reordered", func(lines []string) []string {
+			lines[0], lines[1] = lines[1], lines[0]
+			return lines
+		}, "line 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			tampered := strings.Join(tt.tamper(lines), "\n")
+			_, err := Verify(strings.NewReader(tampered))
+			if !errors.Is(err, ErrTampered) || !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("Verify() error = %v, want ErrTampered at %s", err, tt.line)
+			}
+			if err := os.WriteFile(path, []byte(tampered), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := OpenFile(path); !errors.Is(err, ErrTampered) {
+				t.Errorf("OpenFile() error = %v, want ErrTampered", err)
+			}
+		})
+	}
+}
+
+func TestFileLog_PartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeLog(t, path, "41399675-9")
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a write interrupted by a crash
+	if _, err := f.WriteString(`{"time":"2024-01-02T03:04:05Z","rut":"2222`); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	writeLog(t, path, "42266856-K")
+	if count, err := VerifyFile(path); err != nil || count != 2 {
+		t.Errorf("VerifyFile() = %d, %v, want 2 entries", count, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "2222") {
+		t.Errorf("the partial line was kept:\n%s", data)
+	}
+}
+
+func TestFileLog_Truncated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeLog(t, path, "41399675-9", "42533552-9", "42266856-K")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	// the chain of the first two entries is still intact
+	truncated := strings.Join(lines[:2], "")
+	if count, err := Verify(strings.NewReader(truncated)); err != nil || count != 2 {
+		t.Fatalf("Verify() = %d, %v, want 2 entries", count, err)
+	}
+	if err := os.WriteFile(path, []byte(truncated), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyFile(path); !errors.Is(err, ErrTampered) {
+		t.Errorf("VerifyFile() error = %v, want ErrTampered", err)
+	}
+	if _, err := OpenFile(path); !errors.Is(err, ErrTampered) {
+		t.Errorf("OpenFile() error = %v, want ErrTampered", err)
+	}
+
+	// the last entry replaced by another one with a valid chain
+	other := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeLog(t, other, "41399675-9")
+	cp, err := ReadCheckpoint(path + ".checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := VerifyCheckpoint(f, Checkpoint{Count: 1, Hash: cp.Hash}); !errors.Is(err, ErrTampered) {
+		t.Errorf("VerifyCheckpoint() error = %v, want ErrTampered", err)
+	}
+}
+
+func TestFileLog_FailedWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeLog(t, path, "41399675-9")
+	// a file that cannot be written nor truncated
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	l, err := open(f, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := gosii.AuditEntry{Time: time.Now(), Purpose: "test", Rut: "42533552-9", Outcome: gosii.AuditFound}
+	err = l.Record(entry)
+	if err == nil || l.Record(entry) != err {
+		t.Errorf("Record() error = %v, want the same error on every call", err)
+	}
+	if count, err := VerifyFile(path); err != nil || count != 1 {
+		t.Errorf("VerifyFile() = %d, %v, want 1 entry", count, err)
+	}
+}
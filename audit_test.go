@@ -0,0 +1,85 @@
+package gosii
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memorySink struct {
+	mutex   sync.Mutex
+	entries []AuditEntry
+	err     error
+}
+
+func (s *memorySink) Record(entry AuditEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.entries = append(s.entries, entry)
+	return s.err
+}
+
+func (s *memorySink) recorded() []AuditEntry {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]AuditEntry(nil), s.entries...)
+}
+
+func TestAudit(t *testing.T) {
+	sink := &memorySink{}
+	client := NewSIIClient(&Opts{Audit: sink, RequirePurpose: true})
+
+	if _, _, err := client.GetNameByRUT("41399675-9"); !errors.Is(err, ErrMissingPurpose) {
+		t.Errorf("GetNameByRUT() error = %v, want ErrMissingPurpose", err)
+	}
+	if len(sink.entries) != 0 {
+		t.Errorf("entries = %+v, want none", sink.entries)
+	}
+
+	ctx := WithAuditInfo(context.Background(), AuditInfo{Purpose: "KYC #12", UserID: "u1"})
+	// a wrong check digit is rejected before any request is made
+	if _, _, err := client.GetNameByRUTContext(ctx, "41399675-K"); err == nil {
+		t.Errorf("GetNameByRUTContext() error = nil")
+	}
+	if len(sink.entries) != 1 {
+		t.Fatalf("entries = %+v, want 1", sink.entries)
+	}
+	entry := sink.entries[0]
+	if entry.Purpose != "KYC #12" || entry.UserID != "u1" || entry.Rut != "41399675-K" ||
+		entry.Outcome != AuditInvalidRUT || entry.Error == "" || entry.Time.IsZero() {
+		t.Errorf("entry = %+v", entry)
+	}
+
+	sink.err = errors.New("disk full")
+	if _, _, err := client.GetNameByRUTContext(ctx, "41399675-K"); !errors.Is(err, ErrAudit) {
+		t.Errorf("GetNameByRUTContext() error = %v, want ErrAudit", err)
+	}
+}
+
+func TestAudit_StaleWhileRevalidate(t *testing.T) {
+	sink := &memorySink{}
+	cache := NewMemoryCache()
+	_ = cache.Set(cachedRUT, &Citizen{Name: "COMERCIAL PINA SPA"}, time.Now().Add(-90*time.Minute))
+	client := newFakeClient(&Opts{Audit: sink, RequirePurpose: true, Cache: cache}, newFakeSII("COMERCIAL PINA DOS SPA"))
+
+	ctx := WithAuditInfo(context.Background(), AuditInfo{Purpose: "KYC #12", UserID: "u1"})
+	if _, meta, err := client.GetNameByRUTContext(ctx, cachedRUT); err != nil || !meta.Stale {
+		t.Fatalf("GetNameByRUTContext() = %+v, %v, want stale", meta, err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(sink.recorded()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("the refresh was not audited: %+v", sink.recorded())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// the lookup of the user is recorded once, and the refresh as the client's own
+	entries := sink.recorded()
+	if len(entries) != 2 ||
+		entries[0].UserID != "u1" || entries[0].Purpose != "KYC #12" ||
+		entries[1].UserID != "" || entries[1].Purpose != AuditPurposeRefresh || entries[1].Outcome != AuditFound {
+		t.Errorf("entries = %+v", entries)
+	}
+}
@@ -0,0 +1,170 @@
+package gosii
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+const (
+	// SourceCache is the RequestMetadata.Source of the results served from Opts.Cache.
+	SourceCache = "cache"
+
+	defaultCacheTTL             = time.Hour
+	defaultStaleWhileRevalidate = time.Hour
+	defaultMaxStale             = 24 * time.Hour
+	// refreshTimeout bounds the background refreshes, which are not bound to the caller.
+	refreshTimeout = time.Minute
+)
+
+// Cache keeps the last citizen fetched for each RUT, in the "12345678-9" format.
+// Implementations must be safe for concurrent use (see NewMemoryCache, and store.AsCache
+// to use a store).
+type Cache interface {
+	// Get returns the citizen and the time it was fetched, or ErrNotFound.
+	Get(rut string) (*Citizen, time.Time, error)
+	Set(rut string, citizen *Citizen, fetchedAt time.Time) error
+}
+
+type memoryCacheEntry struct {
+	citizen   *Citizen
+	fetchedAt time.Time
+}
+
+type memoryCache struct {
+	mutex   sync.RWMutex
+	entries map[string]memoryCacheEntry
+}
+
+// NewMemoryCache returns a Cache that keeps the citizens in memory, without limit.
+func NewMemoryCache() Cache {
+	return &memoryCache{entries: map[string]memoryCacheEntry{}}
+}
+
+func (c *memoryCache) Get(rut string) (*Citizen, time.Time, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	entry, ok := c.entries[rut]
+	if !ok {
+		return nil, time.Time{}, ErrNotFound
+	}
+	return entry.citizen, entry.fetchedAt, nil
+}
+
+func (c *memoryCache) Set(rut string, citizen *Citizen, fetchedAt time.Time) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[rut] = memoryCacheEntry{citizen: citizen, fetchedAt: fetchedAt}
+	return nil
+}
+
+// cachedLookup serves the lookups from Opts.Cache when it can:
+//   - a citizen younger than CacheTTL is returned as is;
+//   - a stale citizen, up to StaleWhileRevalidate past CacheTTL, is returned right away
+//     while it is fetched again in the background;
+//   - otherwise the citizen is fetched, and if the SII is unavailable a stale citizen,
+//     up to MaxStale past CacheTTL, is returned instead of the error. When there is such
+//     a citizen, the SII is tried only once, so that it is returned without waiting for
+//     the retries.
+func (c *SIIClient) cachedLookup(ctx context.Context, info AuditInfo, rut string) (*Citizen, *RequestMetadata, error) {
+	parsed, err := pkg.ParseRUT(rut)
+	if err != nil {
+		return c.fetch(ctx, info, rut)
+	}
+	key := parsed.String()
+	ttl := durationOr(c.opts.CacheTTL, defaultCacheTTL)
+	cached, fetchedAt, cacheErr := c.opts.Cache.Get(key)
+	hasCached := cacheErr == nil && cached != nil
+	age := time.Since(fetchedAt)
+	switch {
+	case hasCached && age < ttl:
+		return c.serveCached(info, rut, cached, age, false)
+	case hasCached && age < ttl+durationOr(c.opts.StaleWhileRevalidate, defaultStaleWhileRevalidate):
+		c.refresh(detach(ctx), key)
+		return c.serveCached(info, rut, cached, age, true)
+	}
+
+	canServeStale := hasCached && age < ttl+durationOr(c.opts.MaxStale, defaultMaxStale)
+	fetchCtx := ctx
+	if canServeStale {
+		fetchCtx = withSingleAttempt(ctx)
+	}
+	citizen, meta, err := c.fetch(fetchCtx, info, rut)
+	if err == nil {
+		_ = c.opts.Cache.Set(key, citizen, time.Now())
+		return citizen, meta, nil
+	}
+	if canServeStale && isUnavailable(err) {
+		return c.serveCached(info, rut, cached, age, true)
+	}
+	return nil, meta, err
+}
+
+func (c *SIIClient) serveCached(info AuditInfo, rut string, citizen *Citizen, age time.Duration, stale bool) (*Citizen, *RequestMetadata, error) {
+	if c.opts.Audit != nil {
+		if err := c.audit(info, rut, nil, nil); err != nil {
+			return nil, nil, err
+		}
+	}
+	meta := &RequestMetadata{
+		TotalCount: int(c.requestCount.Load()),
+		Source:     SourceCache,
+		Stale:      stale,
+		Age:        age,
+	}
+	return citizen, meta, nil
+}
+
+// refresh fetches a RUT in the background and updates the cache, unless it is
+// already being refreshed. The lookup of the caller is already audited by serveCached, so
+// the refresh is audited as the client's own, with AuditPurposeRefresh and no user.
+func (c *SIIClient) refresh(ctx context.Context, key string) {
+	if _, running := c.refreshing.LoadOrStore(key, true); running {
+		return
+	}
+	go func() {
+		defer c.refreshing.Delete(key)
+		ctx, cancel := context.WithTimeout(ctx, refreshTimeout)
+		defer cancel()
+		citizen, _, err := c.fetch(ctx, AuditInfo{Purpose: AuditPurposeRefresh}, key)
+		if err == nil {
+			_ = c.opts.Cache.Set(key, citizen, time.Now())
+		}
+	}()
+}
+
+// isUnavailable reports whether err means that the SII could not answer (a transport error,
+// a timeout, a page that cannot be parsed...), as opposed to an answer such as ErrNotFound.
+func isUnavailable(err error) bool {
+	return !errors.Is(err, ErrNotFound) &&
+		!errors.Is(err, pkg.ErrInvalidRUT) &&
+		!errors.Is(err, pkg.ErrInvalidDV) &&
+		!errors.Is(err, ErrMissingPurpose) &&
+		!errors.Is(err, ErrAudit) &&
+		!errors.Is(err, context.Canceled)
+}
+
+func durationOr(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+// detachedContext keeps the values of its parent (e.g. the audit info) but not its
+// cancellation, for the work that outlives the call.
+type detachedContext struct {
+	context.Context
+	parent context.Context
+}
+
+func detach(parent context.Context) context.Context {
+	return detachedContext{Context: context.Background(), parent: parent}
+}
+
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
+}
@@ -0,0 +1,98 @@
+package gosii
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const cachedRUT = "79517385-4"
+
+func TestCache(t *testing.T) {
+	sii := newFakeSII("COMERCIAL PINA SPA")
+	client := newFakeClient(&Opts{Cache: NewMemoryCache()}, sii)
+
+	ctz, meta, err := client.GetNameByRUT("79.517.385-4")
+	if err != nil || ctz.Name != "COMERCIAL PINA SPA" || meta.Source != SourceSII {
+		t.Fatalf("GetNameByRUT() = %+v, %+v, %v", ctz, meta, err)
+	}
+	ctz, meta, err = client.GetNameByRUT(cachedRUT)
+	if err != nil || ctz.Name != "COMERCIAL PINA SPA" || meta.Source != SourceCache || meta.Stale {
+		t.Errorf("GetNameByRUT() = %+v, %+v, %v, want fresh from cache", ctz, meta, err)
+	}
+	if n := sii.lookupCount(); n != 1 {
+		t.Errorf("lookups = %d, want 1", n)
+	}
+}
+
+func TestCache_StaleWhileRevalidate(t *testing.T) {
+	sii := newFakeSII("COMERCIAL PINA DOS SPA")
+	cache := NewMemoryCache()
+	_ = cache.Set(cachedRUT, &Citizen{Name: "COMERCIAL PINA SPA"}, time.Now().Add(-90*time.Minute))
+	client := newFakeClient(&Opts{Cache: cache}, sii)
+
+	ctz, meta, err := client.GetNameByRUT(cachedRUT)
+	if err != nil || ctz.Name != "COMERCIAL PINA SPA" || !meta.Stale || meta.Age < 90*time.Minute {
+		t.Fatalf("GetNameByRUT() = %+v, %+v, %v, want stale", ctz, meta, err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		refreshed, fetchedAt, _ := cache.Get(cachedRUT)
+		if refreshed.Name == "COMERCIAL PINA DOS SPA" && time.Since(fetchedAt) < time.Minute {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the cache was not refreshed: %+v", refreshed)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCache_StaleIfError(t *testing.T) {
+	sii := newFakeSII("COMERCIAL PINA SPA")
+	sii.setDown(true)
+	cache := NewMemoryCache()
+	client := newFakeClient(&Opts{Cache: cache, CacheTTL: time.Hour, StaleWhileRevalidate: time.Minute, MaxStale: 24 * time.Hour}, sii)
+
+	_ = cache.Set(cachedRUT, &Citizen{Name: "COMERCIAL PINA SPA"}, time.Now().Add(-5*time.Hour))
+	ctz, meta, err := client.GetNameByRUT(cachedRUT)
+	if err != nil || ctz.Name != "COMERCIAL PINA SPA" || !meta.Stale || meta.Source != SourceCache {
+		t.Errorf("GetNameByRUT() = %+v, %+v, %v, want stale", ctz, meta, err)
+	}
+
+	_ = cache.Set(cachedRUT, &Citizen{Name: "COMERCIAL PINA SPA"}, time.Now().Add(-30*time.Hour))
+	if _, _, err := client.GetNameByRUT(cachedRUT); !errors.Is(err, ErrMaxCaptchaAttempts) {
+		t.Errorf("GetNameByRUT() error = %v, want ErrMaxCaptchaAttempts past MaxStale", err)
+	}
+}
+
+func TestCache_StaleIfErrorWithoutRetries(t *testing.T) {
+	sii := newFakeSII("COMERCIAL PINA SPA")
+	sii.setLookupsDown(true)
+	cache := NewMemoryCache()
+	client := newFakeClient(&Opts{Cache: cache, CacheTTL: time.Hour, StaleWhileRevalidate: time.Minute}, sii)
+	_ = cache.Set(cachedRUT, &Citizen{Name: "COMERCIAL PINA SPA"}, time.Now().Add(-5*time.Hour))
+
+	// the retries wait up to 7 seconds each; the stale citizen is served after the first failure
+	start := time.Now()
+	ctz, meta, err := client.GetNameByRUT(cachedRUT)
+	if err != nil || ctz.Name != "COMERCIAL PINA SPA" || !meta.Stale {
+		t.Fatalf("GetNameByRUT() = %+v, %+v, %v, want stale", ctz, meta, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetNameByRUT() took %v, want the stale citizen right away", elapsed)
+	}
+	if n := sii.lookupCount(); n != 1 {
+		t.Errorf("lookups = %d, want 1", n)
+	}
+}
+
+func TestCache_NotFoundIsNotHidden(t *testing.T) {
+	sii := newFakeSII("**")
+	cache := NewMemoryCache()
+	_ = cache.Set(cachedRUT, &Citizen{Name: "COMERCIAL PINA SPA"}, time.Now().Add(-5*time.Hour))
+	client := newFakeClient(&Opts{Cache: cache, StaleWhileRevalidate: time.Minute}, sii)
+	if _, _, err := client.GetNameByRUT(cachedRUT); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetNameByRUT() error = %v, want ErrNotFound", err)
+	}
+}
@@ -1,10 +1,12 @@
 package gosii
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"io"
+	"net/http"
 	"strings"
 )
 
@@ -39,13 +41,16 @@ type Captcha struct {
 //
 // Please note that this method relies on the structure of SII's captcha service and its response.
 // If the service URL or the response structure changes, this method may not work as expected.
-func (c *siiHTTPClient) fetchCaptcha() (*Captcha, error) {
-	remAttempts := 3
+func (c *SIIClient) fetchCaptcha(ctx context.Context) (*Captcha, error) {
+	remAttempts := attemptsFromContext(ctx)
 	for {
-		captcha, err := c.fetchCaptchaAtt()
+		captcha, err := c.fetchCaptchaAtt(ctx)
 		if err == nil && captcha != nil && captcha.Text != "" {
 			return captcha, nil
 		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		remAttempts--
 		if remAttempts == 0 {
 			return nil, ErrMaxCaptchaAttempts
@@ -53,14 +58,18 @@ func (c *siiHTTPClient) fetchCaptcha() (*Captcha, error) {
 	}
 }
 
-func (c *siiHTTPClient) fetchCaptchaAtt() (*Captcha, error) {
+func (c *SIIClient) fetchCaptchaAtt(ctx context.Context) (*Captcha, error) {
 	c.requestCount.Add(1)
-	resp, err := c.httpClient.Post(
-		siiCaptchaURL, "application/json", strings.NewReader("oper=0"),
-	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, siiCaptchaURL, strings.NewReader("oper=0"))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 	captchaResp := CaptchaResp{}
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -0,0 +1,120 @@
+package gosii
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+// Source wraps a Client to give it a name and tell Chain how to treat its answers.
+type Source struct {
+	// Name is reported in RequestMetadata.Source when the source produces the result.
+	Name   string
+	Client Client
+	// NotFoundIsFinal stops the chain when the source returns ErrNotFound, i.e. the source
+	// is authoritative: if it does not know the RUT, nobody else does.
+	NotFoundIsFinal bool
+	// StopOnError stops the chain when the source fails with an error other than
+	// ErrNotFound (e.g. a transport error). By default the next source is tried.
+	StopOnError bool
+}
+
+func (s *Source) GetNameByRUT(rut string) (*Citizen, *RequestMetadata, error) {
+	return s.Client.GetNameByRUT(rut)
+}
+
+func (s *Source) GetNameByRUTContext(ctx context.Context, rut string) (*Citizen, *RequestMetadata, error) {
+	return LookupContext(ctx, s.Client, rut)
+}
+
+type chainClient struct {
+	sources []*Source
+}
+
+// Chain returns a Client that tries the clients in order and returns the first citizen found.
+//
+// Clients can be wrapped in a *Source to name them and set how their errors are treated.
+// Unnamed clients keep the source they report in their RequestMetadata (e.g. "sii") or are
+// named after their position ("source-0", "source-1", ...). For plain clients, the chain
+// moves on to the next client both on ErrNotFound and on any other error.
+//
+// Invalid RUTs stop the chain right away. When no client finds the citizen, Chain returns
+// ErrNotFound only if every client answered ErrNotFound; otherwise it returns the first
+// error that was not ErrNotFound, because the RUT may exist in the source that failed.
+//
+// The RequestMetadata of the result tells which source produced it in its Source field.
+func Chain(clients ...Client) ContextClient {
+	sources := make([]*Source, 0, len(clients))
+	for _, client := range clients {
+		source, ok := client.(*Source)
+		if !ok {
+			source = &Source{Client: client}
+		}
+		sources = append(sources, source)
+	}
+	return &chainClient{sources: sources}
+}
+
+func (c *chainClient) GetNameByRUT(rut string) (*Citizen, *RequestMetadata, error) {
+	return c.GetNameByRUTContext(context.Background(), rut)
+}
+
+func (c *chainClient) GetNameByRUTContext(ctx context.Context, rut string) (*Citizen, *RequestMetadata, error) {
+	var firstErr error
+	var lastMeta *RequestMetadata
+	for i, source := range c.sources {
+		if err := ctx.Err(); err != nil {
+			return nil, lastMeta, err
+		}
+		citizen, meta, err := LookupContext(ctx, source.Client, rut)
+		if meta != nil {
+			lastMeta = meta
+		}
+		name := sourceName(source, meta, i)
+		if err == nil && citizen != nil {
+			return citizen, withSource(meta, name), nil
+		}
+		if err == nil {
+			err = ErrNotFound
+		}
+		switch {
+		case errors.Is(err, pkg.ErrInvalidRUT) || errors.Is(err, pkg.ErrInvalidDV):
+			return nil, withSource(meta, name), err
+		case errors.Is(err, ErrNotFound):
+			if source.NotFoundIsFinal {
+				return nil, withSource(meta, name), ErrNotFound
+			}
+		default:
+			if source.StopOnError {
+				return nil, withSource(meta, name), err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr != nil {
+		return nil, lastMeta, firstErr
+	}
+	return nil, lastMeta, ErrNotFound
+}
+
+func withSource(meta *RequestMetadata, source string) *RequestMetadata {
+	if meta == nil {
+		meta = &RequestMetadata{}
+	}
+	meta.Source = source
+	return meta
+}
+
+func sourceName(source *Source, meta *RequestMetadata, position int) string {
+	if source.Name != "" {
+		return source.Name
+	}
+	if meta != nil && meta.Source != "" {
+		return meta.Source
+	}
+	return fmt.Sprintf("source-%d", position)
+}
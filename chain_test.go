@@ -0,0 +1,148 @@
+package gosii
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+var errTransport = errors.New("connection reset")
+
+type fakeClient struct {
+	citizen *Citizen
+	meta    *RequestMetadata
+	err     error
+	calls   int
+}
+
+func (c *fakeClient) GetNameByRUT(rut string) (*Citizen, *RequestMetadata, error) {
+	return c.GetNameByRUTContext(context.Background(), rut)
+}
+
+func (c *fakeClient) GetNameByRUTContext(context.Context, string) (*Citizen, *RequestMetadata, error) {
+	c.calls++
+	return c.citizen, c.meta, c.err
+}
+
+func TestChain(t *testing.T) {
+	found := &Citizen{Name: "FOUND"}
+	tests := []struct {
+		name       string
+		clients    func() []Client
+		wantName   string
+		wantSource string
+		wantErr    error
+	}{
+		{
+			name: "first found wins",
+			clients: func() []Client {
+				return []Client{
+					&fakeClient{err: ErrNotFound},
+					&Source{Name: "cache", Client: &fakeClient{citizen: found}},
+					&fakeClient{citizen: &Citizen{Name: "LATER"}},
+				}
+			},
+			wantName:   "FOUND",
+			wantSource: "cache",
+		},
+		{
+			name: "unnamed client keeps its source",
+			clients: func() []Client {
+				return []Client{&fakeClient{citizen: found, meta: &RequestMetadata{Source: SourceSII}}}
+			},
+			wantName:   "FOUND",
+			wantSource: SourceSII,
+		},
+		{
+			name: "transport error moves on",
+			clients: func() []Client {
+				return []Client{&fakeClient{err: errTransport}, &fakeClient{citizen: found}}
+			},
+			wantName:   "FOUND",
+			wantSource: "source-1",
+		},
+		{
+			name: "authoritative not found stops",
+			clients: func() []Client {
+				return []Client{
+					&Source{Name: "master", Client: &fakeClient{err: ErrNotFound}, NotFoundIsFinal: true},
+					&fakeClient{citizen: found},
+				}
+			},
+			wantSource: "master",
+			wantErr:    ErrNotFound,
+		},
+		{
+			name: "stop on error",
+			clients: func() []Client {
+				return []Client{
+					&Source{Name: "dataset", Client: &fakeClient{err: errTransport}, StopOnError: true},
+					&fakeClient{citizen: found},
+				}
+			},
+			wantSource: "dataset",
+			wantErr:    errTransport,
+		},
+		{
+			name: "invalid rut stops",
+			clients: func() []Client {
+				return []Client{&fakeClient{err: pkg.ErrInvalidDV}, &fakeClient{citizen: found}}
+			},
+			wantSource: "source-0",
+			wantErr:    pkg.ErrInvalidDV,
+		},
+		{
+			name: "transport error wins over not found",
+			clients: func() []Client {
+				return []Client{&fakeClient{err: ErrNotFound}, &fakeClient{err: errTransport}}
+			},
+			wantErr: errTransport,
+		},
+		{
+			name: "all not found",
+			clients: func() []Client {
+				return []Client{&fakeClient{err: ErrNotFound}, &fakeClient{}}
+			},
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			citizen, meta, err := Chain(tt.clients()...).GetNameByRUT("79517385-4")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetNameByRUT() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantName != "" && (citizen == nil || citizen.Name != tt.wantName) {
+				t.Errorf("GetNameByRUT() citizen = %+v, want %s", citizen, tt.wantName)
+			}
+			if tt.wantSource != "" && (meta == nil || meta.Source != tt.wantSource) {
+				t.Errorf("GetNameByRUT() meta = %+v, want source %s", meta, tt.wantSource)
+			}
+		})
+	}
+}
+
+// plainClient is a Client without GetNameByRUTContext.
+type plainClient struct{ fake fakeClient }
+
+func (c *plainClient) GetNameByRUT(rut string) (*Citizen, *RequestMetadata, error) {
+	return c.fake.GetNameByRUT(rut)
+}
+
+func TestLookupContext(t *testing.T) {
+	plain := &plainClient{fakeClient{citizen: &Citizen{Name: "X"}}}
+	var client Client = plain
+	if _, ok := client.(ContextClient); ok {
+		t.Fatal("plainClient is a ContextClient")
+	}
+	if citizen, _, err := LookupContext(context.Background(), client, "1-9"); err != nil || citizen.Name != "X" {
+		t.Errorf("LookupContext() = %+v, %v", citizen, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := LookupContext(ctx, client, "1-9"); !errors.Is(err, context.Canceled) || plain.fake.calls != 1 {
+		t.Errorf("LookupContext() of a canceled context = %v after %d calls", err, plain.fake.calls)
+	}
+}
@@ -0,0 +1,77 @@
+package gosii
+
+import (
+	"bytes"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// siiDefaultCharset is the charset used by the SII pages when neither the
+// Content-Type header nor the document declares one.
+const siiDefaultCharset = "iso-8859-1"
+
+var metaCharsetRegex = regexp.MustCompile(`(?i)<meta[^>]+charset\s*=\s*["']?\s*([a-z0-9_\-:]+)`)
+
+// windows1252 maps the 0x80-0x9F range of Windows-1252 to its Unicode code points.
+// The rest of the range is shared with ISO-8859-1 (i.e., byte value == code point).
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// decodeBody converts the raw body of an SII response to a UTF-8 string.
+//
+// The charset is taken from the Content-Type header and, if it is missing, from the
+// <meta> tags of the document. When no charset is declared, the body is kept as is
+// if it is already valid UTF-8 and decoded as ISO-8859-1 otherwise, because that is
+// what the SII serves.
+func decodeBody(body []byte, contentType string) string {
+	charset := detectCharset(body, contentType)
+	switch charset {
+	case "utf-8", "utf8":
+		return string(body)
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1", "l1", "iso_8859-1", "us-ascii", "ascii":
+		return decodeLatin1(body, false)
+	case "windows-1252", "cp1252", "x-cp1252":
+		return decodeLatin1(body, true)
+	}
+	if utf8.Valid(body) {
+		return string(body)
+	}
+	return decodeLatin1(body, false)
+}
+
+func detectCharset(body []byte, contentType string) string {
+	if contentType != "" {
+		_, params, err := mime.ParseMediaType(contentType)
+		if err == nil && params["charset"] != "" {
+			return strings.ToLower(params["charset"])
+		}
+	}
+	head := body
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	if m := metaCharsetRegex.FindSubmatch(head); m != nil {
+		return string(bytes.ToLower(m[1]))
+	}
+	if utf8.Valid(body) {
+		return "utf-8"
+	}
+	return siiDefaultCharset
+}
+
+func decodeLatin1(body []byte, cp1252 bool) string {
+	var sb strings.Builder
+	sb.Grow(len(body) + len(body)/4)
+	for _, b := range body {
+		if cp1252 && b >= 0x80 && b <= 0x9F {
+			sb.WriteRune(windows1252[b-0x80])
+			continue
+		}
+		sb.WriteRune(rune(b))
+	}
+	return sb.String()
+}
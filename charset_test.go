@@ -0,0 +1,40 @@
+package gosii
+
+import "testing"
+
+func TestDecodeBody(t *testing.T) {
+	latin1 := []byte("<html><body>P\xc9REZ \xd1\xda\xd1EZ</body></html>")
+	tests := []struct {
+		name        string
+		body        []byte
+		contentType string
+		want        string
+	}{
+		{"header charset", latin1, "text/html; charset=ISO-8859-1", "<html><body>PÉREZ ÑÚÑEZ</body></html>"},
+		{"no charset latin1", latin1, "text/html", "<html><body>PÉREZ ÑÚÑEZ</body></html>"},
+		{"no charset utf8", []byte("PIÑERA"), "", "PIÑERA"},
+		{"meta charset", []byte("<meta charset=\"windows-1252\">\x93PI\xd1A\x94"), "", "<meta charset=\"windows-1252\">“PIÑA”"},
+		{"meta http-equiv", []byte("<meta http-equiv=\"Content-Type\" content=\"text/html; charset=iso-8859-1\">\xc1"), "", "<meta http-equiv=\"Content-Type\" content=\"text/html; charset=iso-8859-1\">Á"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeBody(tt.body, tt.contentType); got != tt.want {
+				t.Errorf("decodeBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"José Miguel Pérez Ñúñez":      "JOSE MIGUEL PEREZ NUNEZ",
+		"  COMERCIAL  O'HIGGINS S.A. ": "COMERCIAL OHIGGINS SA",
+		"PÉREZ-GÜEMES, MARÍA":          "PEREZ GUEMES MARIA",
+		"":                             "",
+	}
+	for in, want := range tests {
+		if got := NormalizeName(in); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,11 +1,46 @@
 package gosii
 
+import (
+	"context"
+	"time"
+)
+
 type RequestMetadata struct {
 	TotalCount int     `json:"total_count"`
 	AvgTime    float64 `json:"avg_time"`
 	Attempts   int     `json:"attempts"`
+	// Source is the name of the source that produced the result (e.g. "sii").
+	Source string `json:"source,omitempty"`
+	// ParserVersion is the version of the ResponseParser that parsed the page of the SII.
+	ParserVersion string `json:"parser_version,omitempty"`
+	// Stale is set when the citizen comes from the cache and is older than Opts.CacheTTL.
+	Stale bool `json:"stale,omitempty"`
+	// Age is the time since the citizen was fetched, when it comes from the cache.
+	Age time.Duration `json:"age,omitempty"`
+	// QueueWait is the time the lookup waited for Opts.Limiter.
+	QueueWait time.Duration `json:"queue_wait,omitempty"`
 }
 
 type Client interface {
 	GetNameByRUT(rut string) (*Citizen, *RequestMetadata, error)
 }
+
+// ContextClient is a Client whose lookups can be bound to a context.
+type ContextClient interface {
+	Client
+	// GetNameByRUTContext is like GetNameByRUT, but bound to ctx. The context also
+	// carries per-call data such as the audit info (see WithAuditInfo).
+	GetNameByRUTContext(ctx context.Context, rut string) (*Citizen, *RequestMetadata, error)
+}
+
+// LookupContext looks up a RUT with client, bound to ctx when client is a ContextClient.
+// Other clients are called with GetNameByRUT once ctx is checked not to be done.
+func LookupContext(ctx context.Context, client Client, rut string) (*Citizen, *RequestMetadata, error) {
+	if c, ok := client.(ContextClient); ok {
+		return c.GetNameByRUTContext(ctx, rut)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return client.GetNameByRUT(rut)
+}
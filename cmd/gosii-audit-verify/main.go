@@ -0,0 +1,29 @@
+// Command gosii-audit-verify checks the hash chain of a gosii audit log, and its checkpoint
+// (audit.jsonl.checkpoint) when there is one.
+//
+// Usage:
+//
+//	gosii-audit-verify audit.jsonl
+//
+// It exits with status 1 if the log was tampered with.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Eitol/gosii/audit"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: gosii-audit-verify <audit log>")
+		os.Exit(2)
+	}
+	count, err := audit.VerifyFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("OK: %d entries\n", count)
+}
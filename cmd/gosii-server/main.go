@@ -0,0 +1,84 @@
+// Command gosii-server serves the gosii lookups and batch jobs over HTTP (see package server),
+// and over gRPC with -grpc-addr (see package grpcserver). Both share the limiter of the client.
+//
+// Usage:
+//
+//	gosii-server -addr :8080 -grpc-addr :9090 -jobs ./jobs -concurrency 2 -interval 1s
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/gosiipb"
+	"github.com/Eitol/gosii/grpcserver"
+	"github.com/Eitol/gosii/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	grpcAddr := flag.String("grpc-addr", "", "address to serve gRPC on (disabled when empty)")
+	jobsDir := flag.String("jobs", "jobs", "directory where the jobs are kept")
+	workers := flag.Int("workers", 1, "jobs processed at the same time")
+	concurrency := flag.Int("concurrency", 1, "lookups to the SII at the same time")
+	batchLimit := flag.Int("batch-concurrency", 0, "lookups of the jobs at the same time (0 for no cap)")
+	interval := flag.Duration("interval", time.Second, "minimum time between two lookups to the SII")
+	flag.Parse()
+
+	limiter := gosii.LimiterOpts{MaxConcurrent: *concurrency, MinInterval: *interval}
+	if *batchLimit > 0 {
+		limiter.ClassLimits = map[gosii.Priority]int{gosii.PriorityBatch: *batchLimit}
+	}
+	client := gosii.NewSIIClient(&gosii.Opts{Limiter: gosii.NewLimiter(limiter)})
+	srv, err := server.New(&server.Opts{
+		Client:  client,
+		JobsDir: *jobsDir,
+		Workers: *workers,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	httpServer := &http.Server{Addr: *addr, Handler: srv}
+	var grpcServer *grpc.Server
+	if *grpcAddr != "" {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		grpcServer = grpc.NewServer()
+		gosiipb.RegisterTaxpayerServiceServer(grpcServer, grpcserver.New(&grpcserver.Opts{Client: client}))
+		go func() {
+			log.Printf("serving gRPC on %s", *grpcAddr)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+		if grpcServer != nil {
+			grpcServer.GracefulStop()
+		}
+	}()
+	log.Printf("listening on %s", *addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	_ = srv.Close()
+}
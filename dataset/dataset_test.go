@@ -0,0 +1,111 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Eitol/gosii"
+)
+
+type staticClient struct {
+	citizen *gosii.Citizen
+	calls   int
+}
+
+func (c *staticClient) GetNameByRUT(rut string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	return c.GetNameByRUTContext(context.Background(), rut)
+}
+
+func (c *staticClient) GetNameByRUTContext(context.Context, string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	c.calls++
+	return c.citizen, &gosii.RequestMetadata{}, nil
+}
+
+func buildIndex(t *testing.T) *Index {
+	t.Helper()
+	dir := t.TempDir()
+	legalEntities := filepath.Join(dir, "personas_juridicas.txt")
+	// ISO-8859-1 encoded, with header and a separate DV column
+	err := os.WriteFile(legalEntities, []byte("RUT;DV;RAZON SOCIAL\n79517385;4;COMERCIAL PI\xd1A SPA\n41399675;K;INVALID DV\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuers := filepath.Join(dir, "emisores.csv")
+	err = os.WriteFile(issuers, []byte("79.913.744-5,EMPRESAS DEL SUR S.A.\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexPath := filepath.Join(dir, "index", "taxpayers.idx")
+	n, err := Import(indexPath, legalEntities, issuers)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Import() = %d, want 2", n)
+	}
+	idx, err := Open(indexPath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = idx.Close() })
+	return idx
+}
+
+func TestIndex_GetNameByRUT(t *testing.T) {
+	idx := buildIndex(t)
+	ctz, _, err := idx.GetNameByRUT("79.517.385-4")
+	if err != nil {
+		t.Fatalf("GetNameByRUT() error = %v", err)
+	}
+	if ctz.Name != "COMERCIAL PIÑA SPA" || ctz.Kind != gosii.KindCompany || ctz.LegalForm != gosii.LegalFormSpA {
+		t.Errorf("GetNameByRUT() = %+v", ctz)
+	}
+	ctz, _, err = idx.GetNameByRUT("79913744-5")
+	if err != nil || ctz.Name != "EMPRESAS DEL SUR S.A." {
+		t.Errorf("GetNameByRUT() = %+v, %v", ctz, err)
+	}
+	if _, _, err = idx.GetNameByRUT("41399675-9"); !errors.Is(err, gosii.ErrNotFound) {
+		t.Errorf("GetNameByRUT() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWithFallback(t *testing.T) {
+	idx := buildIndex(t)
+	live := &staticClient{citizen: &gosii.Citizen{Name: "LIVE"}}
+	client := WithFallback(idx, live)
+	ctz, _, err := client.GetNameByRUT("79517385-4")
+	if err != nil || ctz.Name != "COMERCIAL PIÑA SPA" || live.calls != 0 {
+		t.Errorf("GetNameByRUT() = %+v, %v, live calls %d", ctz, err, live.calls)
+	}
+	ctz, _, err = client.GetNameByRUT("41399675-9")
+	if err != nil || ctz.Name != "LIVE" || live.calls != 1 {
+		t.Errorf("GetNameByRUT() = %+v, %v, live calls %d", ctz, err, live.calls)
+	}
+}
+
+func TestOpen_InvalidIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.idx")
+	if err := os.WriteFile(path, []byte("not an index"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); !errors.Is(err, ErrInvalidIndex) {
+		t.Errorf("Open() error = %v, want ErrInvalidIndex", err)
+	}
+}
+
+func TestTruncateName(t *testing.T) {
+	// "Ñ" is 2 bytes and straddles the limit
+	name := strings.Repeat("A", maxNameLength-1) + "Ñ"
+	got := truncateName(name)
+	if !utf8.ValidString(got) || len(got) != maxNameLength-1 {
+		t.Errorf("truncateName() = %d bytes, valid UTF-8 %v", len(got), utf8.ValidString(got))
+	}
+	if got := truncateName("PIÑA"); got != "PIÑA" {
+		t.Errorf("truncateName() = %q", got)
+	}
+}
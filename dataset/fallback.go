@@ -0,0 +1,15 @@
+package dataset
+
+import (
+	"github.com/Eitol/gosii"
+)
+
+// WithFallback returns a client that looks up the RUT in primary (usually an Index)
+// and only asks fallback (usually the live client) when primary returns gosii.ErrNotFound.
+// Other errors of primary are returned as is.
+func WithFallback(primary gosii.Client, fallback gosii.Client) gosii.ContextClient {
+	return gosii.Chain(
+		&gosii.Source{Name: SourceDataset, Client: primary, StopOnError: true},
+		fallback,
+	)
+}
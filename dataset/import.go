@@ -0,0 +1,181 @@
+package dataset
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+var ErrUnknownFormat = errors.New("unknown dataset format")
+
+// columns holds the position of the fields of a nómina. dv is -1 when the RUT
+// column includes the check digit.
+type columns struct {
+	rut  int
+	dv   int
+	name int
+}
+
+// Import reads the nóminas published by the SII at srcs and writes an index with all
+// their taxpayers at dst. When a RUT appears more than once, the last name wins.
+//
+// The files can be separated by ";", tab, "," or "|", be encoded in UTF-8 or ISO-8859-1
+// and have a header row. The RUT, check digit and name ("razón social") columns are
+// found by their headers; without headers, the columns are assumed to be RUT, DV and
+// name, or RUT (with DV) and name.
+//
+// It returns the number of taxpayers in the index.
+func Import(dst string, srcs ...string) (int, error) {
+	entries := map[int]string{}
+	for _, src := range srcs {
+		f, err := os.Open(src)
+		if err != nil {
+			return 0, err
+		}
+		err = importReader(f, entries)
+		_ = f.Close()
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := writeIndex(dst, entries); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+func importReader(r io.Reader, entries map[int]string) error {
+	br := bufio.NewReader(r)
+	firstLine, err := br.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return err
+	}
+	if idx := bytes.IndexByte(firstLine, '\n'); idx >= 0 {
+		firstLine = firstLine[:idx]
+	}
+	reader := csv.NewReader(br)
+	reader.Comma = detectDelimiter(firstLine)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	var cols *columns
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for i := range record {
+			record[i] = strings.TrimSpace(toUTF8(record[i]))
+		}
+		if cols == nil {
+			cols, err = detectColumns(record)
+			if err != nil {
+				return err
+			}
+			if isHeader(record) {
+				continue
+			}
+		}
+		number, name, ok := parseRecord(record, cols)
+		if ok {
+			entries[number] = name
+		}
+	}
+	return nil
+}
+
+func detectDelimiter(line []byte) rune {
+	best, bestCount := ';', 0
+	for _, d := range []rune{';', '\t', ',', '|'} {
+		if n := bytes.Count(line, []byte(string(d))); n > bestCount {
+			best, bestCount = d, n
+		}
+	}
+	return best
+}
+
+func isHeader(record []string) bool {
+	if len(record) == 0 {
+		return false
+	}
+	_, err := pkg.ParseRUT(record[0])
+	return err != nil && !isDigits(record[0])
+}
+
+func detectColumns(record []string) (*columns, error) {
+	if !isHeader(record) {
+		if len(record) >= 3 && len(record[1]) == 1 {
+			return &columns{rut: 0, dv: 1, name: 2}, nil
+		}
+		if len(record) >= 2 {
+			return &columns{rut: 0, dv: -1, name: 1}, nil
+		}
+		return nil, ErrUnknownFormat
+	}
+	cols := &columns{rut: -1, dv: -1, name: -1}
+	for i, field := range record {
+		header := pkg.NormalizeText(field)
+		switch {
+		case cols.rut < 0 && strings.Contains(header, "RUT"):
+			cols.rut = i
+		case cols.dv < 0 && (header == "DV" || strings.Contains(header, "DIGITO")):
+			cols.dv = i
+		case cols.name < 0 && (strings.Contains(header, "RAZON SOCIAL") || strings.Contains(header, "NOMBRE")):
+			cols.name = i
+		}
+	}
+	if cols.rut < 0 || cols.name < 0 {
+		return nil, ErrUnknownFormat
+	}
+	return cols, nil
+}
+
+func parseRecord(record []string, cols *columns) (int, string, bool) {
+	if cols.rut >= len(record) || cols.name >= len(record) || cols.dv >= len(record) {
+		return 0, "", false
+	}
+	rutStr := record[cols.rut]
+	if cols.dv >= 0 {
+		rutStr += "-" + record[cols.dv]
+	}
+	rut, err := pkg.ParseRUT(rutStr)
+	if err != nil || record[cols.name] == "" {
+		return 0, "", false
+	}
+	return rut.Number, record[cols.name], true
+}
+
+// toUTF8 decodes s as ISO-8859-1 if it is not valid UTF-8.
+func toUTF8(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	runes := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		runes[i] = rune(s[i])
+	}
+	return string(runes)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,215 @@
+// Package dataset answers RUT lookups offline from the nóminas (taxpayer lists) that the
+// Servicio de Impuestos Internos (SII) publishes, e.g. the list of legal entities or the
+// list of electronic-invoice issuers.
+//
+// The nóminas are imported with Import into a compact index file, which is opened with
+// Open. The index implements gosii.Client, so it can be used in place of the live client,
+// or in front of it with WithFallback.
+package dataset
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/pkg"
+)
+
+// The index file is made of a header (magic and number of entries), the entries sorted
+// by RUT number (number and offset of the name, 4 bytes each) and the names (2 bytes of
+// length followed by the name in UTF-8).
+const (
+	indexMagic    = "GOSIIDX1"
+	headerSize    = len(indexMagic) + 4
+	entrySize     = 8
+	maxNameLength = 1<<16 - 1
+	tmpSuffix     = ".tmp"
+)
+
+// SourceDataset is the RequestMetadata.Source of the results found in an Index.
+const SourceDataset = "dataset"
+
+var ErrInvalidIndex = errors.New("invalid dataset index")
+
+// Index is an index of taxpayers opened from disk. It is safe for concurrent use.
+type Index struct {
+	f           *os.File
+	count       int
+	namesOffset int64
+	lookupCount atomic.Uint64
+}
+
+// Open opens an index written by Import.
+func Open(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(f, header); err != nil || string(header[:len(indexMagic)]) != indexMagic {
+		_ = f.Close()
+		return nil, ErrInvalidIndex
+	}
+	count := int(binary.BigEndian.Uint32(header[len(indexMagic):]))
+	return &Index{
+		f:           f,
+		count:       count,
+		namesOffset: int64(headerSize + count*entrySize),
+	}, nil
+}
+
+// Len returns the number of taxpayers in the index.
+func (i *Index) Len() int {
+	return i.count
+}
+
+func (i *Index) Close() error {
+	return i.f.Close()
+}
+
+// GetNameByRUT returns the name of the taxpayer with the given RUT from the index.
+// The citizen has no activities because the nóminas do not include them.
+//
+// Returns gosii.ErrNotFound if the RUT is not in the index.
+func (i *Index) GetNameByRUT(rut string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	return i.GetNameByRUTContext(context.Background(), rut)
+}
+
+// GetNameByRUTContext is like GetNameByRUT. The lookup is local, so ctx is only checked
+// before it starts.
+func (i *Index) GetNameByRUTContext(ctx context.Context, rut string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	startTime := time.Now()
+	count := i.lookupCount.Add(1)
+	parsed, err := pkg.ParseRUT(rut)
+	if err != nil {
+		return nil, nil, err
+	}
+	name, found, err := i.Lookup(parsed.Number)
+	meta := &gosii.RequestMetadata{
+		TotalCount: int(count),
+		AvgTime:    float64(time.Since(startTime)),
+		Attempts:   1,
+		Source:     SourceDataset,
+	}
+	if err != nil {
+		return nil, meta, err
+	}
+	if !found {
+		return nil, meta, gosii.ErrNotFound
+	}
+	ctz := &gosii.Citizen{Rut: parsed, Name: name}
+	ctz.Classify()
+	return ctz, meta, nil
+}
+
+// Lookup returns the name stored for a RUT number.
+func (i *Index) Lookup(number int) (string, bool, error) {
+	var readErr error
+	entry := make([]byte, entrySize)
+	pos := sort.Search(i.count, func(n int) bool {
+		if readErr != nil {
+			return true
+		}
+		if _, err := i.f.ReadAt(entry, int64(headerSize+n*entrySize)); err != nil {
+			readErr = err
+			return true
+		}
+		return int(binary.BigEndian.Uint32(entry)) >= number
+	})
+	if readErr != nil {
+		return "", false, readErr
+	}
+	if pos >= i.count {
+		return "", false, nil
+	}
+	if _, err := i.f.ReadAt(entry, int64(headerSize+pos*entrySize)); err != nil {
+		return "", false, err
+	}
+	if int(binary.BigEndian.Uint32(entry)) != number {
+		return "", false, nil
+	}
+	nameOffset := i.namesOffset + int64(binary.BigEndian.Uint32(entry[4:]))
+	length := make([]byte, 2)
+	if _, err := i.f.ReadAt(length, nameOffset); err != nil {
+		return "", false, err
+	}
+	name := make([]byte, binary.BigEndian.Uint16(length))
+	if _, err := i.f.ReadAt(name, nameOffset+2); err != nil {
+		return "", false, err
+	}
+	return string(name), true, nil
+}
+
+func writeIndex(dst string, entries map[int]string) error {
+	numbers := make([]int, 0, len(entries))
+	for number := range entries {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	tmp := dst + tmpSuffix
+	if dir := filepath.Dir(dst); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	buf := make([]byte, entrySize)
+	_, _ = w.WriteString(indexMagic)
+	binary.BigEndian.PutUint32(buf, uint32(len(numbers)))
+	_, _ = w.Write(buf[:4])
+	offset := uint32(0)
+	for _, number := range numbers {
+		name := truncateName(entries[number])
+		binary.BigEndian.PutUint32(buf, uint32(number))
+		binary.BigEndian.PutUint32(buf[4:], offset)
+		_, _ = w.Write(buf)
+		offset += uint32(2 + len(name))
+	}
+	for _, number := range numbers {
+		name := truncateName(entries[number])
+		binary.BigEndian.PutUint16(buf, uint16(len(name)))
+		_, _ = w.Write(buf[:2])
+		_, _ = w.WriteString(name)
+	}
+	err = w.Flush()
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// truncateName cuts the name to maxNameLength bytes at a rune boundary, so it stays valid UTF-8.
+func truncateName(name string) string {
+	if len(name) <= maxNameLength {
+		return name
+	}
+	end := maxNameLength
+	for end > 0 && !utf8.RuneStart(name[end]) {
+		end--
+	}
+	return name[:end]
+}
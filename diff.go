@@ -0,0 +1,216 @@
+package gosii
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Language string
+
+const (
+	LangEnglish Language = "en"
+	LangSpanish Language = "es"
+)
+
+// FieldChange is the change of a single field between two snapshots.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ActivityChange is an activity that is present in both snapshots with different data.
+type ActivityChange struct {
+	Code string             `json:"code"`
+	Old  CommercialActivity `json:"old"`
+	New  CommercialActivity `json:"new"`
+}
+
+// CitizenDiff is the structured difference between two snapshots of the same citizen.
+type CitizenDiff struct {
+	Rut               string               `json:"rut"`
+	OldName           string               `json:"old_name,omitempty"`
+	NewName           string               `json:"new_name,omitempty"`
+	AddedActivities   []CommercialActivity `json:"added_activities,omitempty"`
+	RemovedActivities []CommercialActivity `json:"removed_activities,omitempty"`
+	ChangedActivities []ActivityChange     `json:"changed_activities,omitempty"`
+	// StatusChanges holds the changes of the tax-status fields (kind, legal form,
+	// business ended, end date and observations).
+	StatusChanges []FieldChange `json:"status_changes,omitempty"`
+}
+
+var diffLabels = map[Language]map[string]string{
+	LangEnglish: {
+		"none":           "No changes",
+		"name":           "Name",
+		"added":          "Activity added",
+		"removed":        "Activity removed",
+		"changed":        "Activity changed",
+		"kind":           "Kind",
+		"legal_form":     "Legal form",
+		"business_ended": "Business ended",
+		"end_date":       "End of business date",
+		"observations":   "Observations",
+	},
+	LangSpanish: {
+		"none":           "Sin cambios",
+		"name":           "Nombre",
+		"added":          "Actividad agregada",
+		"removed":        "Actividad eliminada",
+		"changed":        "Actividad modificada",
+		"kind":           "Tipo de contribuyente",
+		"legal_form":     "Forma jurídica",
+		"business_ended": "Término de giro",
+		"end_date":       "Fecha de término de giro",
+		"observations":   "Observaciones",
+	},
+}
+
+// Diff compares two snapshots of a citizen. Activities are matched by code.
+// A nil snapshot is treated as an empty citizen.
+func Diff(older, newer *Citizen) CitizenDiff {
+	if older == nil {
+		older = &Citizen{}
+	}
+	if newer == nil {
+		newer = &Citizen{}
+	}
+	d := CitizenDiff{Rut: newer.Rut.String()}
+	if d.Rut == "" {
+		d.Rut = older.Rut.String()
+	}
+	if older.Name != newer.Name {
+		d.OldName = older.Name
+		d.NewName = newer.Name
+	}
+
+	oldByCode := map[string]CommercialActivity{}
+	for _, a := range older.Activities {
+		oldByCode[a.Code] = a
+	}
+	newByCode := map[string]bool{}
+	for _, a := range newer.Activities {
+		newByCode[a.Code] = true
+		previous, ok := oldByCode[a.Code]
+		switch {
+		case !ok:
+			d.AddedActivities = append(d.AddedActivities, a)
+		case previous != a:
+			d.ChangedActivities = append(d.ChangedActivities, ActivityChange{Code: a.Code, Old: previous, New: a})
+		}
+	}
+	for _, a := range older.Activities {
+		if !newByCode[a.Code] {
+			d.RemovedActivities = append(d.RemovedActivities, a)
+		}
+	}
+
+	d.addStatusChange("kind", string(older.Kind), string(newer.Kind))
+	d.addStatusChange("legal_form", string(older.LegalForm), string(newer.LegalForm))
+	d.addStatusChange("business_ended", strconv.FormatBool(older.BusinessEnded), strconv.FormatBool(newer.BusinessEnded))
+	d.addStatusChange("end_date", formatDate(older.EndDate), formatDate(newer.EndDate))
+	d.addStatusChange("observations", formatObservations(older.Observations), formatObservations(newer.Observations))
+	return d
+}
+
+// NameChanged reports whether the name changed.
+func (d CitizenDiff) NameChanged() bool {
+	return d.OldName != d.NewName
+}
+
+// Empty reports whether nothing changed.
+func (d CitizenDiff) Empty() bool {
+	return !d.NameChanged() &&
+		len(d.AddedActivities) == 0 &&
+		len(d.RemovedActivities) == 0 &&
+		len(d.ChangedActivities) == 0 &&
+		len(d.StatusChanges) == 0
+}
+
+// diffValues translates the values of the status fields, by language and field.
+var diffValues = map[Language]map[string]map[string]string{
+	LangSpanish: {
+		"kind": {
+			string(KindPerson):  "persona natural",
+			string(KindCompany): "persona jurídica",
+			string(KindUnknown): "desconocido",
+		},
+		"business_ended": {"true": "sí", "false": "no"},
+	},
+}
+
+// Render returns a human-readable description of the changes, one per line,
+// in English or Spanish (e.g. for audit emails). Unknown languages fall back to English.
+//
+// Example:
+//
+//	Nombre: "COMERCIAL PINA SPA" -> "COMERCIAL PIÑA SPA"
+//	Actividad agregada: 479100 VENTA AL POR MENOR POR CORREO, POR INTERNET Y VIA TELEFONICA
+func (d CitizenDiff) Render(lang Language) string {
+	labels, ok := diffLabels[lang]
+	if !ok {
+		labels = diffLabels[LangEnglish]
+	}
+	if d.Empty() {
+		return labels["none"]
+	}
+	var lines []string
+	if d.NameChanged() {
+		lines = append(lines, fmt.Sprintf("%s: %q -> %q", labels["name"], d.OldName, d.NewName))
+	}
+	for _, a := range d.AddedActivities {
+		lines = append(lines, fmt.Sprintf("%s: %s", labels["added"], formatActivity(a)))
+	}
+	for _, a := range d.RemovedActivities {
+		lines = append(lines, fmt.Sprintf("%s: %s", labels["removed"], formatActivity(a)))
+	}
+	for _, c := range d.ChangedActivities {
+		lines = append(lines, fmt.Sprintf("%s: %s -> %s", labels["changed"], formatActivity(c.Old), formatActivity(c.New)))
+	}
+	for _, c := range d.StatusChanges {
+		label := labels[c.Field]
+		if label == "" {
+			label = c.Field
+		}
+		values := diffValues[lang][c.Field]
+		lines = append(lines, fmt.Sprintf("%s: %q -> %q", label, translateValue(values, c.Old), translateValue(values, c.New)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (d *CitizenDiff) addStatusChange(field, older, newer string) {
+	if older != newer {
+		d.StatusChanges = append(d.StatusChanges, FieldChange{Field: field, Old: older, New: newer})
+	}
+}
+
+func translateValue(values map[string]string, value string) string {
+	if translated, ok := values[value]; ok {
+		return translated
+	}
+	return value
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatObservations(observations []Observation) string {
+	texts := make([]string, 0, len(observations))
+	for _, o := range observations {
+		texts = append(texts, o.Text)
+	}
+	return strings.Join(texts, "; ")
+}
+
+func formatActivity(a CommercialActivity) string {
+	if a.Name == "" {
+		return a.Code
+	}
+	return a.Code + " " + a.Name
+}
@@ -0,0 +1,112 @@
+package gosii
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+func TestDiff(t *testing.T) {
+	older := &Citizen{
+		Rut:  pkg.MustParseRUT("79517385-4"),
+		Name: "COMERCIAL PINA SPA",
+		Kind: KindCompany,
+		Activities: []CommercialActivity{
+			{Code: "471100"},
+			{Code: "620100", Name: "PROGRAMACION"},
+		},
+	}
+	newer := &Citizen{
+		Rut:       pkg.MustParseRUT("79517385-4"),
+		Name:      "COMERCIAL PIÑA SPA",
+		Kind:      KindCompany,
+		LegalForm: LegalFormSpA,
+		Activities: []CommercialActivity{
+			{Code: "620100", Name: "ACTIVIDADES DE PROGRAMACION INFORMATICA"},
+			{Code: "479100"},
+		},
+	}
+	d := Diff(older, newer)
+	if !d.NameChanged() || d.OldName != older.Name || d.NewName != newer.Name {
+		t.Errorf("Diff() name = %q -> %q", d.OldName, d.NewName)
+	}
+	if len(d.AddedActivities) != 1 || d.AddedActivities[0].Code != "479100" {
+		t.Errorf("Diff() added = %+v", d.AddedActivities)
+	}
+	if len(d.RemovedActivities) != 1 || d.RemovedActivities[0].Code != "471100" {
+		t.Errorf("Diff() removed = %+v", d.RemovedActivities)
+	}
+	if len(d.ChangedActivities) != 1 || d.ChangedActivities[0].Code != "620100" {
+		t.Errorf("Diff() changed = %+v", d.ChangedActivities)
+	}
+	if len(d.StatusChanges) != 1 || d.StatusChanges[0] != (FieldChange{"legal_form", "", "SpA"}) {
+		t.Errorf("Diff() status = %+v", d.StatusChanges)
+	}
+
+	es := d.Render(LangSpanish)
+	for _, want := range []string{
+		`Nombre: "COMERCIAL PINA SPA" -> "COMERCIAL PIÑA SPA"`,
+		"Actividad agregada: 479100",
+		"Actividad eliminada: 471100",
+		"Actividad modificada: 620100 PROGRAMACION -> 620100 ACTIVIDADES DE PROGRAMACION INFORMATICA",
+		`Forma jurídica: "" -> "SpA"`,
+	} {
+		if !strings.Contains(es, want) {
+			t.Errorf("Render(es) = %s\nmissing %q", es, want)
+		}
+	}
+	if en := d.Render(LangEnglish); !strings.Contains(en, "Activity added: 479100") {
+		t.Errorf("Render(en) = %s", en)
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	ctz := &Citizen{Name: "ANA ROJAS", Activities: []CommercialActivity{{Code: "960909"}}}
+	d := Diff(ctz, ctz)
+	if !d.Empty() {
+		t.Errorf("Diff() = %+v, want empty", d)
+	}
+	if got := d.Render(LangSpanish); got != "Sin cambios" {
+		t.Errorf("Render() = %q", got)
+	}
+}
+
+func TestDiff_BusinessEnded(t *testing.T) {
+	endDate := time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)
+	older := &Citizen{Name: "DISTRIBUIDORA LOS AROMOS LIMITADA"}
+	newer := &Citizen{
+		Name:          "DISTRIBUIDORA LOS AROMOS LIMITADA",
+		BusinessEnded: true,
+		EndDate:       &endDate,
+		Observations:  []Observation{{Text: "Domicilio no ubicado"}},
+	}
+	d := Diff(older, newer)
+	want := []FieldChange{
+		{"business_ended", "false", "true"},
+		{"end_date", "", "2024-12-31"},
+		{"observations", "", "Domicilio no ubicado"},
+	}
+	if len(d.StatusChanges) != len(want) {
+		t.Fatalf("Diff() status = %+v, want %+v", d.StatusChanges, want)
+	}
+	for i := range want {
+		if d.StatusChanges[i] != want[i] {
+			t.Errorf("Diff() status[%d] = %+v, want %+v", i, d.StatusChanges[i], want[i])
+		}
+	}
+	if es := d.Render(LangSpanish); !strings.Contains(es, `Término de giro: "no" -> "sí"`) {
+		t.Errorf("Render(es) = %s", es)
+	}
+}
+
+func TestDiff_RenderKind(t *testing.T) {
+	d := Diff(&Citizen{Kind: KindUnknown}, &Citizen{Kind: KindCompany})
+	if es := d.Render(LangSpanish); es != `Tipo de contribuyente: "desconocido" -> "persona jurídica"` {
+		t.Errorf("Render(es) = %s", es)
+	}
+	if en := d.Render(LangEnglish); en != `Kind: "unknown" -> "company"` {
+		t.Errorf("Render(en) = %s", en)
+	}
+}
@@ -0,0 +1,289 @@
+package gosii
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+// siiDTEReceiversURL is the URL from where the list of taxpayers authorized to receive
+// electronic tax documents (DTE) is downloaded.
+var siiDTEReceiversURL = "https://palena.sii.cl/cvc_cgi/dte/ce_empresas_dwnld"
+
+var ErrInvalidDTEList = errors.New("invalid dte receivers list")
+
+// SourceDTEReceivers is the RequestMetadata.Source of the results of a DTERegistry.
+const SourceDTEReceivers = "sii-dte"
+
+const (
+	defaultDTEListTTL = 24 * time.Hour
+	dteResolutionDate = "02-01-2006"
+	// dteRetryInterval is the time between two reloads of an expired list that fail.
+	dteRetryInterval = time.Minute
+)
+
+// DTERowError is a line of the list of DTE receivers that could not be read.
+type DTERowError struct {
+	Line int
+	Err  error
+}
+
+func (e DTERowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// DTERowsError is returned by ParseDTEReceivers with the lines it skipped. It matches
+// ErrInvalidDTEList.
+type DTERowsError struct {
+	Rows []DTERowError
+}
+
+func (e *DTERowsError) Error() string {
+	msg := fmt.Sprintf("%v: %d invalid lines", ErrInvalidDTEList, len(e.Rows))
+	if len(e.Rows) > 0 {
+		msg += ", first " + e.Rows[0].Error()
+	}
+	return msg
+}
+
+func (e *DTERowsError) Is(target error) bool {
+	return target == ErrInvalidDTEList
+}
+
+type DTERegistryOpts struct {
+	// Path of a local copy of the list. When empty, the list is downloaded from URL.
+	Path string
+	// URL from where the list is downloaded. Defaults to the SII's list.
+	URL string
+	// TTL is the time after which the list is loaded again. Defaults to 24 hours.
+	TTL time.Duration
+	// OnLoad is called each time the list is loaded, with the number of receivers.
+	OnLoad func(count int)
+	// OnError is called when a reload fails (the previous list is kept meanwhile) and
+	// with the *DTERowsError of a list loaded with invalid lines.
+	OnError func(err error)
+}
+
+// DTERegistry resolves if a taxpayer is authorized to receive electronic tax documents
+// (DTE) and its exchange email, from the list published by the SII.
+//
+// The list is loaded on the first lookup and kept in memory until its TTL expires. The
+// expired list keeps being used while it is loaded again, and when the reload fails.
+type DTERegistry struct {
+	opts       DTERegistryOpts
+	httpClient *http.Client
+	// mutex guards receivers, loadedAt and failedAt; loadMutex is held while the list is
+	// downloaded, so the lookups are not blocked by it.
+	mutex        sync.Mutex
+	loadMutex    sync.Mutex
+	receivers    map[int]DTEReceiver
+	loadedAt     time.Time
+	failedAt     time.Time
+	requestCount atomic.Uint64
+	// now returns the current time; the tests replace it to expire the list.
+	now func() time.Time
+}
+
+func NewDTERegistry(opts *DTERegistryOpts) *DTERegistry {
+	if opts == nil {
+		opts = &DTERegistryOpts{}
+	}
+	o := *opts
+	if o.URL == "" {
+		o.URL = siiDTEReceiversURL
+	}
+	if o.TTL <= 0 {
+		o.TTL = defaultDTEListTTL
+	}
+	return &DTERegistry{opts: o, httpClient: buildHTTPClient(), now: time.Now}
+}
+
+// GetDTEReceiver returns the DTE exchange data of the taxpayer with the given RUT.
+// The RUT must be provided in the format of "12345678-9" or "12.345.678-9" or "123456789".
+//
+// Returns ErrNotFound if the taxpayer is not an authorized DTE receiver, and pkg.ErrInvalidRUT
+// or pkg.ErrInvalidDV if the RUT is malformed or its check digit is wrong.
+func (r *DTERegistry) GetDTEReceiver(rut string) (*DTEReceiver, *RequestMetadata, error) {
+	parsed, err := pkg.ParseRUT(rut)
+	if err != nil {
+		return nil, nil, err
+	}
+	startTime := time.Now()
+	receivers, loadedAt, err := r.assertList()
+	meta := &RequestMetadata{
+		TotalCount: int(r.requestCount.Load()),
+		AvgTime:    float64(time.Since(startTime)),
+		Attempts:   1,
+		Source:     SourceDTEReceivers,
+	}
+	if err != nil {
+		return nil, meta, err
+	}
+	if age := r.now().Sub(loadedAt); age >= r.opts.TTL {
+		meta.Stale = true
+		meta.Age = age
+	}
+	receiver, ok := receivers[parsed.Number]
+	if !ok {
+		return nil, meta, ErrNotFound
+	}
+	return &receiver, meta, nil
+}
+
+// assertList returns the list and when it was loaded, loading it if it expired.
+func (r *DTERegistry) assertList() (map[int]DTEReceiver, time.Time, error) {
+	r.mutex.Lock()
+	receivers, loadedAt, failedAt := r.receivers, r.loadedAt, r.failedAt
+	r.mutex.Unlock()
+	now := r.now()
+	if receivers != nil && (now.Sub(loadedAt) < r.opts.TTL || now.Sub(failedAt) < dteRetryInterval) {
+		return receivers, loadedAt, nil
+	}
+	if receivers != nil {
+		// serve the expired list instead of waiting for another lookup that is loading it
+		if !r.loadMutex.TryLock() {
+			return receivers, loadedAt, nil
+		}
+	} else {
+		r.loadMutex.Lock()
+	}
+	defer r.loadMutex.Unlock()
+
+	r.mutex.Lock()
+	if r.loadedAt.After(loadedAt) {
+		receivers, loadedAt = r.receivers, r.loadedAt
+		r.mutex.Unlock()
+		return receivers, loadedAt, nil
+	}
+	r.mutex.Unlock()
+
+	loaded, err := r.loadList()
+	var rowsErr *DTERowsError
+	if errors.As(err, &rowsErr) && len(loaded) > 0 {
+		r.reportError(err)
+		err = nil
+	}
+	if err != nil {
+		if receivers == nil {
+			return nil, loadedAt, err
+		}
+		r.mutex.Lock()
+		r.failedAt = r.now()
+		r.mutex.Unlock()
+		r.reportError(err)
+		return receivers, loadedAt, nil
+	}
+	r.mutex.Lock()
+	r.receivers = loaded
+	r.loadedAt = r.now()
+	loadedAt = r.loadedAt
+	r.mutex.Unlock()
+	if r.opts.OnLoad != nil {
+		r.opts.OnLoad(len(loaded))
+	}
+	return loaded, loadedAt, nil
+}
+
+func (r *DTERegistry) reportError(err error) {
+	if r.opts.OnError != nil {
+		r.opts.OnError(err)
+	}
+}
+
+func (r *DTERegistry) loadList() (map[int]DTEReceiver, error) {
+	if r.opts.Path != "" {
+		body, err := os.ReadFile(r.opts.Path)
+		if err != nil {
+			return nil, err
+		}
+		return ParseDTEReceivers(strings.NewReader(decodeBody(body, "")))
+	}
+	r.requestCount.Add(1)
+	res, err := r.httpClient.Get(r.opts.URL)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Join(ErrInvalidDTEList, errors.New(res.Status))
+	}
+	return ParseDTEReceivers(strings.NewReader(decodeBody(body, res.Header.Get("Content-Type"))))
+}
+
+// ParseDTEReceivers parses the list of DTE receivers in the format published by the SII:
+// one "RUT;RAZON SOCIAL;NUMERO RESOLUCION;FECHA RESOLUCION;MAIL INTERCAMBIO;URL" line per
+// taxpayer, with an optional header. The result is indexed by RUT number.
+//
+// The lines with an invalid RUT or resolution date are skipped and reported in a
+// *DTERowsError, returned along with the receivers of the valid lines.
+func ParseDTEReceivers(r io.Reader) (map[int]DTEReceiver, error) {
+	reader := csv.NewReader(bufio.NewReader(r))
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	receivers := map[int]DTEReceiver{}
+	var badRows []DTERowError
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Join(ErrInvalidDTEList, err)
+		}
+		line, _ := reader.FieldPos(0)
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if len(record) < 5 {
+			badRows = append(badRows, DTERowError{Line: line, Err: fmt.Errorf("%d fields, want at least 5", len(record))})
+			continue
+		}
+		rut, err := pkg.ParseRUT(record[0])
+		if err != nil {
+			if line > 1 {
+				badRows = append(badRows, DTERowError{Line: line, Err: err})
+			}
+			// else the header
+			continue
+		}
+		receiver := DTEReceiver{
+			Rut:              rut,
+			Name:             record[1],
+			ResolutionNumber: record[2],
+			ExchangeEmail:    record[4],
+		}
+		if record[3] != "" {
+			receiver.ResolutionDate, err = time.Parse(dteResolutionDate, record[3])
+			if err != nil {
+				badRows = append(badRows, DTERowError{Line: line, Err: err})
+				continue
+			}
+		}
+		if len(record) > 5 {
+			receiver.URL = record[5]
+		}
+		receivers[rut.Number] = receiver
+	}
+	if len(receivers) == 0 {
+		return nil, ErrInvalidDTEList
+	}
+	if len(badRows) > 0 {
+		return receivers, &DTERowsError{Rows: badRows}
+	}
+	return receivers, nil
+}
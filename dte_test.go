@@ -0,0 +1,126 @@
+package gosii
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+const dteList = "RUT;RAZON SOCIAL;NUMERO RESOLUCION;FECHA RESOLUCION;MAIL INTERCAMBIO;URL\n" +
+	"79517385-4;COMERCIAL PI\xd1A SPA;80;22-08-2014;dte@pina.cl;www.pina.cl\n" +
+	"not a rut;BROKEN LINE;0;;;\n"
+
+func TestDTERegistry_GetDTEReceiver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ce_empresas.csv")
+	if err := os.WriteFile(path, []byte(dteList), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loads := 0
+	registry := NewDTERegistry(&DTERegistryOpts{Path: path, OnLoad: func(int) { loads++ }})
+
+	receiver, meta, err := registry.GetDTEReceiver("79.517.385-4")
+	if err != nil {
+		t.Fatalf("GetDTEReceiver() error = %v", err)
+	}
+	if receiver.Rut.String() != "79517385-4" || receiver.Name != "COMERCIAL PIÑA SPA" || receiver.ExchangeEmail != "dte@pina.cl" ||
+		receiver.ResolutionDate.Year() != 2014 || meta.Source != SourceDTEReceivers {
+		t.Errorf("GetDTEReceiver() = %+v, %+v", receiver, meta)
+	}
+	if _, _, err = registry.GetDTEReceiver("41399675-9"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetDTEReceiver() error = %v, want ErrNotFound", err)
+	}
+	if _, _, err = registry.GetDTEReceiver("79517385-5"); !errors.Is(err, pkg.ErrInvalidDV) {
+		t.Errorf("GetDTEReceiver() error = %v, want ErrInvalidDV", err)
+	}
+	if loads != 1 {
+		t.Errorf("the list was loaded %d times, want 1", loads)
+	}
+}
+
+func TestDTERegistry_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=ISO-8859-1")
+		_, _ = w.Write([]byte(dteList))
+	}))
+	defer server.Close()
+	registry := NewDTERegistry(&DTERegistryOpts{URL: server.URL})
+	receiver, _, err := registry.GetDTEReceiver("795173854")
+	if err != nil || receiver.Name != "COMERCIAL PIÑA SPA" {
+		t.Errorf("GetDTEReceiver() = %+v, %v", receiver, err)
+	}
+}
+
+func TestParseDTEReceivers_BadRows(t *testing.T) {
+	list := dteList + "79517385-4;COMERCIAL PI\xd1A SPA;80;2014-08-22;dte@pina.cl;\n"
+	receivers, err := ParseDTEReceivers(strings.NewReader(list))
+	var rowsErr *DTERowsError
+	if !errors.As(err, &rowsErr) || !errors.Is(err, ErrInvalidDTEList) || len(rowsErr.Rows) != 2 ||
+		rowsErr.Rows[0].Line != 3 || rowsErr.Rows[1].Line != 4 {
+		t.Fatalf("ParseDTEReceivers() error = %v, want lines 3 and 4", err)
+	}
+	if len(receivers) != 1 {
+		t.Errorf("ParseDTEReceivers() = %+v, want the valid line", receivers)
+	}
+}
+
+func TestDTERegistry_Reload(t *testing.T) {
+	var failing atomic.Bool
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			<-release
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=ISO-8859-1")
+		_, _ = w.Write([]byte(dteList))
+	}))
+	defer server.Close()
+	errs := make(chan error, 10)
+	registry := NewDTERegistry(&DTERegistryOpts{URL: server.URL, TTL: time.Hour, OnError: func(err error) { errs <- err }})
+	var elapsed atomic.Int64
+	registry.now = func() time.Time { return time.Now().Add(time.Duration(elapsed.Load())) }
+	if _, _, err := registry.GetDTEReceiver("79517385-4"); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; !errors.Is(err, ErrInvalidDTEList) {
+		t.Errorf("OnError() = %v, want the invalid line", err)
+	}
+
+	failing.Store(true)
+	elapsed.Store(int64(2 * time.Hour))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		receiver, meta, err := registry.GetDTEReceiver("79517385-4")
+		if err != nil || receiver.Name != "COMERCIAL PIÑA SPA" || !meta.Stale {
+			t.Errorf("GetDTEReceiver() after a failed reload = %+v, %+v, %v", receiver, meta, err)
+		}
+	}()
+	// while the reload is in progress the expired list is served right away
+	deadline := time.Now().Add(5 * time.Second)
+	for registry.loadMutex.TryLock() {
+		registry.loadMutex.Unlock()
+		if time.Now().After(deadline) {
+			t.Fatal("the reload did not start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if receiver, meta, err := registry.GetDTEReceiver("79517385-4"); err != nil || receiver == nil || !meta.Stale {
+		t.Errorf("GetDTEReceiver() during the reload = %+v, %+v, %v", receiver, meta, err)
+	}
+	close(release)
+	<-done
+	var rowsErr *DTERowsError
+	if err := <-errs; err == nil || errors.As(err, &rowsErr) {
+		t.Errorf("OnError() = %v, want the failed reload", err)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"github.com/Eitol/gosii"
 	"github.com/Eitol/gosii/pkg"
-	"github.com/mailru/easyjson"
+	"github.com/Eitol/gosii/store"
 	"log"
 	"os"
 	"path/filepath"
@@ -18,7 +18,7 @@ var latestTime *time.Time
 
 const idxFileName = "last_run_idx.txt"
 const outDir = "output"
-const filesPerDir = 10_000
+const storeFileName = "lookups.jsonl"
 
 func saveLastRun(run int) {
 	err := os.WriteFile(idxFileName, []byte(fmt.Sprintf("%d", run)), 0644)
@@ -70,10 +70,11 @@ func main() {
 		}
 		close(jobChan)
 	}()
-	err := os.MkdirAll(outDir, 0755)
+	st, err := store.OpenFile(filepath.Join(outDir, storeFileName))
 	if err != nil {
-		log.Fatalf("Error creating output dir: %s", err)
+		log.Fatalf("Error opening store: %s", err)
 	}
+	defer st.Close()
 	for i := 0; i < nworkers; i++ {
 		go func() {
 			defer wg.Done()
@@ -81,7 +82,7 @@ func main() {
 			for run := range jobChan {
 				dv := pkg.GetRutDv(run)
 				rut := fmt.Sprintf("%d-%s", run, dv)
-				data, err := ssiClient.GetNameByRUT(rut)
+				data, _, err := ssiClient.GetNameByRUT(rut)
 				if err != nil {
 					if errors.Is(err, gosii.ErrNotFound) {
 						continue
@@ -89,40 +90,18 @@ func main() {
 						log.Printf("Error: %s", err)
 					}
 				} else {
+					err = st.Put(store.Record{Rut: rut, Time: time.Now(), Citizen: data})
+					if err != nil {
+						log.Printf("Error saving %s: %s", rut, err)
+						continue
+					}
 					mutex.Lock()
 					saveLastRun(run)
-					saveOutput(data)
 					mutex.Unlock()
-					log.Printf("Found: %s: %s", rut, data)
+					log.Printf("Found: %s: %s", rut, data.Name)
 				}
 			}
 		}()
 	}
 	wg.Wait()
 }
-
-func saveOutput(output *gosii.Citizen) {
-	fileName := buildOutFileName(output)
-	outJson, err := easyjson.Marshal(output)
-	if err != nil {
-		log.Fatalf("Error marshaling output: %s", err)
-	}
-	err = os.WriteFile(fileName, outJson, 0644)
-	if err != nil {
-		log.Fatalf("Error saving output: %s", err)
-	}
-}
-
-func buildOutFileName(output *gosii.Citizen) string {
-	run := output.Rut[:len(output.Rut)-2]
-	runInt, err := strconv.Atoi(run)
-	if err != nil {
-		log.Fatalf("Error converting run: %s", err)
-	}
-	subDir := fmt.Sprintf("%d", runInt/filesPerDir)
-	err = os.MkdirAll(filepath.Join(outDir, subDir), 0755)
-	if err != nil {
-		log.Fatalf("Error creating output dir: %s", err)
-	}
-	return filepath.Join(outDir, subDir, fmt.Sprintf("%s.json", output.Rut))
-}
@@ -0,0 +1,81 @@
+package gosii
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// fakeSII answers the requests of the client in place of the SII.
+type fakeSII struct {
+	mutex sync.Mutex
+	page  []byte
+	down  bool
+	// lookupsDown fails the lookups but not the captchas.
+	lookupsDown bool
+	lookups     int
+}
+
+func newFakeSII(name string) *fakeSII {
+	page, err := os.ReadFile("parsertest/testdata/company.html")
+	if err != nil {
+		panic(err)
+	}
+	return &fakeSII{page: bytes.Replace(page, []byte("COMERCIAL PI\xd1A SPA"), []byte(name), 1)}
+}
+
+// newFakeClient returns a client whose requests go to sii.
+func newFakeClient(opts *Opts, sii *fakeSII) *SIIClient {
+	if opts == nil {
+		opts = &Opts{}
+	}
+	return &SIIClient{opts: *opts, httpClient: &http.Client{Transport: sii}}
+}
+
+func (f *fakeSII) setDown(down bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.down = down
+}
+
+func (f *fakeSII) setLookupsDown(down bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.lookupsDown = down
+}
+
+func (f *fakeSII) lookupCount() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.lookups
+}
+
+func (f *fakeSII) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.down {
+		return nil, errors.New("connection refused")
+	}
+	var body []byte
+	if req.URL.String() == siiCaptchaURL {
+		image := append(bytes.Repeat([]byte{0}, 36), "1234"...)
+		body, _ = json.Marshal(CaptchaResp{TxtCaptcha: base64.StdEncoding.EncodeToString(image)})
+	} else {
+		f.lookups++
+		if f.lookupsDown {
+			return nil, errors.New("connection reset")
+		}
+		body = f.page
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"text/html"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package gosii
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+// FuzzBuildRequest checks that the RUT sent to the SII is the cleaned up RUT: the number
+// without separators and the upper-case check digit.
+func FuzzBuildRequest(f *testing.F) {
+	for _, seed := range []string{"41.817.975-9", "41817.9759", "418179759", "6-k", "41 817 975 9", "", "-"} {
+		f.Add(seed)
+	}
+	c := &SIIClient{}
+	f.Fuzz(func(t *testing.T, rut string) {
+		req, err := c.buildRequest(context.Background(), rut, Captcha{Text: "abc", Solution: "1234"})
+		parsed, parseErr := pkg.ParseRUT(rut)
+		if parseErr != nil {
+			if err == nil {
+				t.Fatalf("buildRequest(%q) accepted an invalid RUT", rut)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("buildRequest(%q) error = %v", rut, err)
+		}
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			t.Fatalf("buildRequest(%q) body = %q: %v", rut, body, err)
+		}
+		if form.Get("RUT") != strconv.Itoa(parsed.Number) || form.Get("DV") != parsed.DV {
+			t.Errorf("buildRequest(%q) RUT = %q, DV = %q, want %d-%s", rut, form.Get("RUT"), form.Get("DV"), parsed.Number, parsed.DV)
+		}
+	})
+}
@@ -0,0 +1,5 @@
+// Package gosiipb has the protobuf messages and the gRPC stubs of the gosii API, generated
+// from gosii.proto. The server is in package grpcserver.
+package gosiipb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gosii.proto
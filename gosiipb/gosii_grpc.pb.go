@@ -0,0 +1,223 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: gosii.proto
+
+// The gosii API: lookups of Chilean taxpayers in the Servicio de Impuestos Internos (SII).
+
+package gosiipb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	TaxpayerService_Lookup_FullMethodName      = "/gosii.v1.TaxpayerService/Lookup"
+	TaxpayerService_BatchLookup_FullMethodName = "/gosii.v1.TaxpayerService/BatchLookup"
+	TaxpayerService_VerifyName_FullMethodName  = "/gosii.v1.TaxpayerService/VerifyName"
+)
+
+// TaxpayerServiceClient is the client API for TaxpayerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TaxpayerServiceClient interface {
+	// Lookup looks up a RUT. A malformed RUT or a wrong check digit is INVALID_ARGUMENT, an
+	// unknown RUT is NOT_FOUND and an SII that does not answer is UNAVAILABLE.
+	Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error)
+	// BatchLookup looks up several RUTs and streams a result for each one, in order, as soon
+	// as it is known. The errors of a RUT are reported in its result and do not end the stream.
+	BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (TaxpayerService_BatchLookupClient, error)
+	// VerifyName looks up a RUT and tells whether it belongs to the claimed name.
+	VerifyName(ctx context.Context, in *VerifyNameRequest, opts ...grpc.CallOption) (*VerifyNameResponse, error)
+}
+
+type taxpayerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTaxpayerServiceClient(cc grpc.ClientConnInterface) TaxpayerServiceClient {
+	return &taxpayerServiceClient{cc}
+}
+
+func (c *taxpayerServiceClient) Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error) {
+	out := new(LookupResponse)
+	err := c.cc.Invoke(ctx, TaxpayerService_Lookup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taxpayerServiceClient) BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (TaxpayerService_BatchLookupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TaxpayerService_ServiceDesc.Streams[0], TaxpayerService_BatchLookup_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taxpayerServiceBatchLookupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TaxpayerService_BatchLookupClient interface {
+	Recv() (*BatchLookupResult, error)
+	grpc.ClientStream
+}
+
+type taxpayerServiceBatchLookupClient struct {
+	grpc.ClientStream
+}
+
+func (x *taxpayerServiceBatchLookupClient) Recv() (*BatchLookupResult, error) {
+	m := new(BatchLookupResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *taxpayerServiceClient) VerifyName(ctx context.Context, in *VerifyNameRequest, opts ...grpc.CallOption) (*VerifyNameResponse, error) {
+	out := new(VerifyNameResponse)
+	err := c.cc.Invoke(ctx, TaxpayerService_VerifyName_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TaxpayerServiceServer is the server API for TaxpayerService service.
+// All implementations must embed UnimplementedTaxpayerServiceServer
+// for forward compatibility
+type TaxpayerServiceServer interface {
+	// Lookup looks up a RUT. A malformed RUT or a wrong check digit is INVALID_ARGUMENT, an
+	// unknown RUT is NOT_FOUND and an SII that does not answer is UNAVAILABLE.
+	Lookup(context.Context, *LookupRequest) (*LookupResponse, error)
+	// BatchLookup looks up several RUTs and streams a result for each one, in order, as soon
+	// as it is known. The errors of a RUT are reported in its result and do not end the stream.
+	BatchLookup(*BatchLookupRequest, TaxpayerService_BatchLookupServer) error
+	// VerifyName looks up a RUT and tells whether it belongs to the claimed name.
+	VerifyName(context.Context, *VerifyNameRequest) (*VerifyNameResponse, error)
+	mustEmbedUnimplementedTaxpayerServiceServer()
+}
+
+// UnimplementedTaxpayerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTaxpayerServiceServer struct {
+}
+
+func (UnimplementedTaxpayerServiceServer) Lookup(context.Context, *LookupRequest) (*LookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedTaxpayerServiceServer) BatchLookup(*BatchLookupRequest, TaxpayerService_BatchLookupServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchLookup not implemented")
+}
+func (UnimplementedTaxpayerServiceServer) VerifyName(context.Context, *VerifyNameRequest) (*VerifyNameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyName not implemented")
+}
+func (UnimplementedTaxpayerServiceServer) mustEmbedUnimplementedTaxpayerServiceServer() {}
+
+// UnsafeTaxpayerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TaxpayerServiceServer will
+// result in compilation errors.
+type UnsafeTaxpayerServiceServer interface {
+	mustEmbedUnimplementedTaxpayerServiceServer()
+}
+
+func RegisterTaxpayerServiceServer(s grpc.ServiceRegistrar, srv TaxpayerServiceServer) {
+	s.RegisterService(&TaxpayerService_ServiceDesc, srv)
+}
+
+func _TaxpayerService_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaxpayerServiceServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaxpayerService_Lookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaxpayerServiceServer).Lookup(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaxpayerService_BatchLookup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchLookupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaxpayerServiceServer).BatchLookup(m, &taxpayerServiceBatchLookupServer{stream})
+}
+
+type TaxpayerService_BatchLookupServer interface {
+	Send(*BatchLookupResult) error
+	grpc.ServerStream
+}
+
+type taxpayerServiceBatchLookupServer struct {
+	grpc.ServerStream
+}
+
+func (x *taxpayerServiceBatchLookupServer) Send(m *BatchLookupResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TaxpayerService_VerifyName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaxpayerServiceServer).VerifyName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaxpayerService_VerifyName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaxpayerServiceServer).VerifyName(ctx, req.(*VerifyNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TaxpayerService_ServiceDesc is the grpc.ServiceDesc for TaxpayerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TaxpayerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gosii.v1.TaxpayerService",
+	HandlerType: (*TaxpayerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lookup",
+			Handler:    _TaxpayerService_Lookup_Handler,
+		},
+		{
+			MethodName: "VerifyName",
+			Handler:    _TaxpayerService_VerifyName_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchLookup",
+			Handler:       _TaxpayerService_BatchLookup_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gosii.proto",
+}
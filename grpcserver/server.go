@@ -0,0 +1,211 @@
+// Package grpcserver serves a gosii.Client over gRPC (see the TaxpayerService of gosiipb).
+//
+//	grpcServer := grpc.NewServer()
+//	gosiipb.RegisterTaxpayerServiceServer(grpcServer, grpcserver.New(&grpcserver.Opts{Client: client}))
+//	_ = grpcServer.Serve(listener)
+//
+// The errors of the client are returned with the status code of Code.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/gosiipb"
+	"github.com/Eitol/gosii/pkg"
+)
+
+const defaultMaxBatch = 1000
+
+type Opts struct {
+	// Client used for the lookups. Defaults to gosii.NewSIIClient(nil).
+	Client gosii.Client
+	// MaxBatch is the maximum number of RUTs of a BatchLookup. Defaults to 1000.
+	MaxBatch int
+}
+
+// Server implements gosiipb.TaxpayerServiceServer.
+type Server struct {
+	gosiipb.UnimplementedTaxpayerServiceServer
+	opts Opts
+}
+
+func New(opts *Opts) *Server {
+	if opts == nil {
+		opts = &Opts{}
+	}
+	o := *opts
+	if o.Client == nil {
+		o.Client = gosii.NewSIIClient(nil)
+	}
+	if o.MaxBatch <= 0 {
+		o.MaxBatch = defaultMaxBatch
+	}
+	return &Server{opts: o}
+}
+
+func (s *Server) Lookup(ctx context.Context, req *gosiipb.LookupRequest) (*gosiipb.LookupResponse, error) {
+	ctx = callContext(ctx, req.GetPriority(), gosii.PriorityNormal, req.GetAudit())
+	citizen, meta, err := gosii.LookupContext(ctx, s.opts.Client, req.GetRut())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &gosiipb.LookupResponse{Citizen: citizenToProto(citizen), Metadata: metadataToProto(meta)}, nil
+}
+
+// BatchLookup looks up the RUTs one after the other, with batch priority by default, and
+// sends the result of each one as soon as it is known.
+func (s *Server) BatchLookup(req *gosiipb.BatchLookupRequest, stream gosiipb.TaxpayerService_BatchLookupServer) error {
+	ruts := req.GetRuts()
+	if len(ruts) == 0 || len(ruts) > s.opts.MaxBatch {
+		return status.Errorf(codes.InvalidArgument, "a batch needs between 1 and %d ruts", s.opts.MaxBatch)
+	}
+	ctx := callContext(stream.Context(), req.GetPriority(), gosii.PriorityBatch, req.GetAudit())
+	for i, rut := range ruts {
+		if err := ctx.Err(); err != nil {
+			return statusError(err)
+		}
+		result := &gosiipb.BatchLookupResult{Index: int32(i), Rut: rut}
+		citizen, meta, err := gosii.LookupContext(ctx, s.opts.Client, rut)
+		if err != nil {
+			if ctx.Err() != nil {
+				return statusError(ctx.Err())
+			}
+			result.ErrorCode = int32(Code(err))
+			result.Error = err.Error()
+		} else {
+			result.Citizen = citizenToProto(citizen)
+			result.Metadata = metadataToProto(meta)
+		}
+		if err := stream.Send(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) VerifyName(ctx context.Context, req *gosiipb.VerifyNameRequest) (*gosiipb.VerifyNameResponse, error) {
+	ctx = callContext(ctx, req.GetPriority(), gosii.PriorityNormal, req.GetAudit())
+	citizen, _, err := gosii.LookupContext(ctx, s.opts.Client, req.GetRut())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	m := gosii.MatchName(citizen.Name, req.GetClaimedName(), &gosii.VerifyOpts{
+		MatchThreshold: req.GetMatchThreshold(),
+		WordThreshold:  req.GetWordThreshold(),
+		Kind:           citizen.Kind,
+	})
+	return &gosiipb.VerifyNameResponse{
+		Rut:          req.GetRut(),
+		ClaimedName:  m.ClaimedName,
+		OfficialName: m.OfficialName,
+		Score:        m.Score,
+		Match:        m.Match,
+		Reasons:      m.Reasons,
+	}, nil
+}
+
+// Code returns the gRPC status code of an error of gosii.Client.
+func Code(err error) codes.Code {
+	switch {
+	case err == nil:
+		return codes.OK
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, pkg.ErrInvalidRUT), errors.Is(err, pkg.ErrInvalidDV):
+		return codes.InvalidArgument
+	case errors.Is(err, gosii.ErrMissingPurpose):
+		return codes.FailedPrecondition
+	case errors.Is(err, gosii.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, gosii.ErrAudit), errors.Is(err, gosii.ErrUnexpectedLayout):
+		return codes.Internal
+	default:
+		// the SII is down, slow or keeps rejecting the captcha
+		return codes.Unavailable
+	}
+}
+
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(Code(err), err.Error())
+}
+
+// callContext sets the priority and the audit info of a call.
+func callContext(ctx context.Context, priority gosiipb.Priority, def gosii.Priority, audit *gosiipb.AuditInfo) context.Context {
+	p := def
+	switch priority {
+	case gosiipb.Priority_PRIORITY_BATCH:
+		p = gosii.PriorityBatch
+	case gosiipb.Priority_PRIORITY_NORMAL:
+		p = gosii.PriorityNormal
+	case gosiipb.Priority_PRIORITY_INTERACTIVE:
+		p = gosii.PriorityInteractive
+	}
+	ctx = gosii.WithPriority(ctx, p)
+	if audit != nil {
+		ctx = gosii.WithAuditInfo(ctx, gosii.AuditInfo{Purpose: audit.GetPurpose(), UserID: audit.GetUserId()})
+	}
+	return ctx
+}
+
+func citizenToProto(c *gosii.Citizen) *gosiipb.Citizen {
+	if c == nil {
+		return nil
+	}
+	pc := &gosiipb.Citizen{
+		Rut:           c.Rut.String(),
+		Run:           c.Run,
+		Name:          c.Name,
+		Kind:          string(c.Kind),
+		LegalForm:     string(c.LegalForm),
+		BusinessEnded: c.BusinessEnded,
+		EndDate:       timestampOrNil(c.EndDate),
+	}
+	if c.PersonName != nil {
+		pc.PersonName = &gosiipb.PersonName{
+			GivenNames:      c.PersonName.GivenNames,
+			PaternalSurname: c.PersonName.PaternalSurname,
+			MaternalSurname: c.PersonName.MaternalSurname,
+		}
+	}
+	for _, a := range c.Activities {
+		pc.Activities = append(pc.Activities, &gosiipb.CommercialActivity{Code: a.Code, Name: a.Name})
+	}
+	for _, o := range c.Observations {
+		pc.Observations = append(pc.Observations, &gosiipb.Observation{Text: o.Text, Date: timestampOrNil(o.Date)})
+	}
+	return pc
+}
+
+func metadataToProto(m *gosii.RequestMetadata) *gosiipb.RequestMetadata {
+	if m == nil {
+		return nil
+	}
+	return &gosiipb.RequestMetadata{
+		Attempts:      int32(m.Attempts),
+		Source:        m.Source,
+		ParserVersion: m.ParserVersion,
+		Stale:         m.Stale,
+		Age:           durationpb.New(m.Age),
+		QueueWait:     durationpb.New(m.QueueWait),
+	}
+}
+
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
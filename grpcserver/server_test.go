@@ -0,0 +1,170 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/gosiipb"
+	"github.com/Eitol/gosii/pkg"
+)
+
+// fakeClient knows a few taxpayers and records the priority of each lookup.
+type fakeClient struct {
+	mutex      sync.Mutex
+	priorities []gosii.Priority
+}
+
+var fakeNames = map[string]string{
+	"79517385-4": "COMERCIAL PINA SPA",
+	"41817975-9": "FUNDACION LOS ANDES",
+}
+
+func (f *fakeClient) GetNameByRUT(rut string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	return f.GetNameByRUTContext(context.Background(), rut)
+}
+
+func (f *fakeClient) GetNameByRUTContext(ctx context.Context, rut string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	f.mutex.Lock()
+	f.priorities = append(f.priorities, gosii.PriorityFromContext(ctx))
+	f.mutex.Unlock()
+	if rut == "60803000-K" {
+		return nil, nil, fmt.Errorf("lookup: %w", gosii.ErrMaxCaptchaAttempts)
+	}
+	parsed, err := pkg.ParseRUT(rut)
+	if err != nil {
+		return nil, nil, err
+	}
+	name, ok := fakeNames[parsed.String()]
+	if !ok {
+		return nil, nil, gosii.ErrNotFound
+	}
+	citizen := &gosii.Citizen{Rut: parsed, Name: name, Kind: gosii.KindCompany, Activities: []gosii.CommercialActivity{{Code: "829900"}}}
+	return citizen, &gosii.RequestMetadata{Attempts: 1, Source: gosii.SourceSII}, nil
+}
+
+func newTestClient(t *testing.T, fake *fakeClient) gosiipb.TaxpayerServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	gosiipb.RegisterTaxpayerServiceServer(grpcServer, New(&Opts{Client: fake, MaxBatch: 3}))
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return gosiipb.NewTaxpayerServiceClient(conn)
+}
+
+func TestLookup(t *testing.T) {
+	fake := &fakeClient{}
+	client := newTestClient(t, fake)
+	ctx := context.Background()
+
+	res, err := client.Lookup(ctx, &gosiipb.LookupRequest{Rut: "79.517.385-4", Priority: gosiipb.Priority_PRIORITY_INTERACTIVE})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := res.GetCitizen()
+	if c.GetRut() != "79517385-4" || c.GetName() != "COMERCIAL PINA SPA" || c.GetKind() != "company" ||
+		c.GetActivities()[0].GetCode() != "829900" || res.GetMetadata().GetSource() != gosii.SourceSII {
+		t.Errorf("Lookup() = %v", res)
+	}
+	if fake.priorities[0] != gosii.PriorityInteractive {
+		t.Errorf("priority = %v, want interactive", fake.priorities[0])
+	}
+
+	for rut, code := range map[string]codes.Code{
+		"41817975-K": codes.InvalidArgument,
+		"41399675-9": codes.NotFound,
+		"60803000-K": codes.Unavailable,
+	} {
+		_, err := client.Lookup(ctx, &gosiipb.LookupRequest{Rut: rut})
+		if status.Code(err) != code {
+			t.Errorf("Lookup(%s) error = %v, want %v", rut, err, code)
+		}
+	}
+}
+
+func TestBatchLookup(t *testing.T) {
+	fake := &fakeClient{}
+	client := newTestClient(t, fake)
+	stream, err := client.BatchLookup(context.Background(), &gosiipb.BatchLookupRequest{Ruts: []string{"79.517.385-4", "41399675-9", "41817975-9"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []*gosiipb.BatchLookupResult
+	for {
+		result, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+	if len(results) != 3 ||
+		results[0].GetCitizen().GetName() != "COMERCIAL PINA SPA" ||
+		results[1].GetErrorCode() != int32(codes.NotFound) || results[1].GetCitizen() != nil ||
+		results[2].GetIndex() != 2 || results[2].GetCitizen().GetName() != "FUNDACION LOS ANDES" {
+		t.Errorf("BatchLookup() = %v", results)
+	}
+	for _, p := range fake.priorities {
+		if p != gosii.PriorityBatch {
+			t.Errorf("priority = %v, want batch", p)
+		}
+	}
+
+	stream, err = client.BatchLookup(context.Background(), &gosiipb.BatchLookupRequest{Ruts: []string{"1-9", "1-9", "1-9", "1-9"}})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("BatchLookup() of too many ruts = %v, want InvalidArgument", err)
+	}
+}
+
+func TestVerifyName(t *testing.T) {
+	client := newTestClient(t, &fakeClient{})
+	res, err := client.VerifyName(context.Background(), &gosiipb.VerifyNameRequest{Rut: "79.517.385-4", ClaimedName: "Comercial Piña SpA"})
+	if err != nil || !res.GetMatch() || res.GetOfficialName() != "COMERCIAL PINA SPA" {
+		t.Errorf("VerifyName() = %v, %v", res, err)
+	}
+	if _, err := client.VerifyName(context.Background(), &gosiipb.VerifyNameRequest{Rut: "41399675-9", ClaimedName: "X"}); status.Code(err) != codes.NotFound {
+		t.Errorf("VerifyName() error = %v, want NotFound", err)
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := map[error]codes.Code{
+		nil:                                 codes.OK,
+		pkg.ErrInvalidDV:                    codes.InvalidArgument,
+		gosii.ErrMissingPurpose:             codes.FailedPrecondition,
+		fmt.Errorf("x: %w", gosii.ErrAudit): codes.Internal,
+		&gosii.LayoutError{}:                codes.Internal,
+		context.DeadlineExceeded:            codes.DeadlineExceeded,
+		errors.New("connection refused"):    codes.Unavailable,
+	}
+	for err, want := range tests {
+		if got := Code(err); got != want {
+			t.Errorf("Code(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package gosii
+
+import "strings"
+
+type CitizenKind string
+
+const (
+	KindUnknown CitizenKind = "unknown"
+	KindPerson  CitizenKind = "person"
+	KindCompany CitizenKind = "company"
+)
+
+type LegalForm string
+
+const (
+	LegalFormSpA                LegalForm = "SpA"
+	LegalFormLtda               LegalForm = "Ltda."
+	LegalFormSA                 LegalForm = "S.A."
+	LegalFormEIRL               LegalForm = "EIRL"
+	LegalFormCooperative        LegalForm = "Cooperativa"
+	LegalFormFoundation         LegalForm = "Fundación"
+	LegalFormCorporation        LegalForm = "Corporación"
+	LegalFormAssociation        LegalForm = "Asociación"
+	LegalFormPartnership        LegalForm = "Sociedad Colectiva"
+	LegalFormLimitedPartnership LegalForm = "Sociedad en Comandita"
+)
+
+type PersonName struct {
+	GivenNames      string `json:"given_names"`
+	PaternalSurname string `json:"paternal_surname"`
+	MaternalSurname string `json:"maternal_surname"`
+}
+
+// legalFormSuffixes are matched against the last tokens of the normalized name.
+// The multi-token suffixes go first because dots are removed by NormalizeName,
+// so "S.A." becomes "SA" while "S. A." becomes "S A".
+var legalFormSuffixes = []struct {
+	tokens []string
+	form   LegalForm
+}{
+	{[]string{"SOCIEDAD", "POR", "ACCIONES"}, LegalFormSpA},
+	{[]string{"SOCIEDAD", "ANONIMA"}, LegalFormSA},
+	{[]string{"S", "P", "A"}, LegalFormSpA},
+	{[]string{"E", "I", "R", "L"}, LegalFormEIRL},
+	{[]string{"S", "A"}, LegalFormSA},
+	{[]string{"SPA"}, LegalFormSpA},
+	{[]string{"LTDA"}, LegalFormLtda},
+	{[]string{"LIMITADA"}, LegalFormLtda},
+	{[]string{"EIRL"}, LegalFormEIRL},
+	{[]string{"SA"}, LegalFormSA},
+}
+
+// legalFormPrefixes are matched against the first token of the normalized name.
+var legalFormPrefixes = map[string]LegalForm{
+	"COOPERATIVA": LegalFormCooperative,
+	"FUNDACION":   LegalFormFoundation,
+	"CORPORACION": LegalFormCorporation,
+	"ASOCIACION":  LegalFormAssociation,
+}
+
+// legalFormKeywords are matched anywhere in the normalized name, before the suffixes.
+var legalFormKeywords = []struct {
+	keyword string
+	form    LegalForm
+}{
+	{"EMPRESA INDIVIDUAL DE RESPONSABILIDAD LIMITADA", LegalFormEIRL},
+	{"SOCIEDAD EN COMANDITA", LegalFormLimitedPartnership},
+	{"SOCIEDAD COLECTIVA", LegalFormPartnership},
+}
+
+// surnameParticles are the words that are part of a compound surname,
+// e.g. "DE LA FUENTE" or "DEL RIO".
+var surnameParticles = map[string]bool{
+	"DE": true, "DEL": true, "LA": true, "LAS": true, "LOS": true,
+	"SAN": true, "SANTA": true, "VAN": true, "VON": true, "MC": true,
+}
+
+// DetectLegalForm returns the legal form of a company given its name, or an
+// empty string if the name does not contain a known legal form.
+//
+// Example: "INVERSIONES EL ROBLE S.P.A." -> LegalFormSpA
+func DetectLegalForm(name string) LegalForm {
+	tokens := strings.Fields(NormalizeName(name))
+	if len(tokens) == 0 {
+		return ""
+	}
+	normalized := strings.Join(tokens, " ")
+	for _, kw := range legalFormKeywords {
+		if strings.Contains(normalized, kw.keyword) {
+			return kw.form
+		}
+	}
+	for _, suffix := range legalFormSuffixes {
+		if len(tokens) > len(suffix.tokens) && hasSuffixTokens(tokens, suffix.tokens) {
+			return suffix.form
+		}
+	}
+	if form, ok := legalFormPrefixes[tokens[0]]; ok {
+		return form
+	}
+	return ""
+}
+
+// SplitPersonName splits the name of a natural person, as written by the SII
+// ("GIVEN NAMES PATERNAL MATERNAL"), into its parts.
+//
+// The split is best-effort: the last two surnames (including particles such as
+// "DE LA") are taken as the paternal and maternal surnames and the rest are the
+// given names. It returns nil if the name has less than two words.
+//
+// Example: "MARIA JOSE DE LA FUENTE SOTO" -> {"MARIA JOSE", "DE LA FUENTE", "SOTO"}
+func SplitPersonName(name string) *PersonName {
+	tokens := strings.Fields(name)
+	if len(tokens) < 2 {
+		return nil
+	}
+	if len(tokens) == 2 {
+		return &PersonName{GivenNames: tokens[0], PaternalSurname: tokens[1]}
+	}
+	maternalStart := surnameStart(tokens, len(tokens)-1, 1)
+	paternalStart := surnameStart(tokens, maternalStart-1, 1)
+	if paternalStart == 0 {
+		// there is no room left for the given names: assume a single surname.
+		return &PersonName{
+			GivenNames:      tokens[0],
+			PaternalSurname: strings.Join(tokens[1:], " "),
+		}
+	}
+	return &PersonName{
+		GivenNames:      strings.Join(tokens[:paternalStart], " "),
+		PaternalSurname: strings.Join(tokens[paternalStart:maternalStart], " "),
+		MaternalSurname: strings.Join(tokens[maternalStart:], " "),
+	}
+}
+
+// surnameStart returns the index where the surname ending at tokens[end] starts,
+// walking back over the particles that precede it. At least minStart tokens are
+// left before the surname.
+func surnameStart(tokens []string, end int, minStart int) int {
+	start := end
+	for start-1 >= minStart && surnameParticles[NormalizeName(tokens[start-1])] {
+		start--
+	}
+	return start
+}
+
+// Classify fills the Kind, LegalForm and PersonName of the citizen. The RUT number range
+// decides the kind; the name is only used when the RUT does not tell (e.g. a wrong check
+// digit), so a person whose name ends in a token like "SA" is still a person. It is called
+// by the client after each lookup.
+func (c *Citizen) Classify() {
+	c.Kind = KindUnknown
+	c.LegalForm = ""
+	c.PersonName = nil
+	switch {
+	case c.Rut.IsValid() && c.Rut.IsCompany():
+		c.Kind = KindCompany
+		c.LegalForm = DetectLegalForm(c.Name)
+	case c.Rut.IsValid():
+		c.Kind = KindPerson
+		c.PersonName = SplitPersonName(c.Name)
+	default:
+		c.LegalForm = DetectLegalForm(c.Name)
+		if c.LegalForm != "" {
+			c.Kind = KindCompany
+		}
+	}
+}
+
+func hasSuffixTokens(tokens []string, suffix []string) bool {
+	offset := len(tokens) - len(suffix)
+	for i, s := range suffix {
+		if tokens[offset+i] != s {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package gosii
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+func TestDetectLegalForm(t *testing.T) {
+	tests := map[string]LegalForm{
+		"INVERSIONES EL ROBLE S.P.A.":                               "SpA",
+		"INVERSIONES EL ROBLE SPA":                                  "SpA",
+		"COMERCIAL LOS ANDES LTDA.":                                 "Ltda.",
+		"TRANSPORTES SUR LIMITADA":                                  "Ltda.",
+		"BANCO DEL SUR S.A.":                                        "S.A.",
+		"BANCO DEL SUR S. A.":                                       "S.A.",
+		"SERVICIOS PEREZ E.I.R.L.":                                  "EIRL",
+		"COOPERATIVA AGRICOLA DEL VALLE":                            "Cooperativa",
+		"FUNDACIÓN NIÑOS DEL MAR":                                   "Fundación",
+		"JUAN PEREZ EMPRESA INDIVIDUAL DE RESPONSABILIDAD LIMITADA": "EIRL",
+		"JOSE MIGUEL PEREZ NUNEZ":                                   "",
+		"SPA":                                                       "",
+	}
+	for name, want := range tests {
+		if got := DetectLegalForm(name); got != want {
+			t.Errorf("DetectLegalForm(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestSplitPersonName(t *testing.T) {
+	tests := []struct {
+		name string
+		want *PersonName
+	}{
+		{"JOSE MIGUEL PEREZ NUNEZ", &PersonName{"JOSE MIGUEL", "PEREZ", "NUNEZ"}},
+		{"MARIA JOSE DE LA FUENTE SOTO", &PersonName{"MARIA JOSE", "DE LA FUENTE", "SOTO"}},
+		{"PEDRO SOTO DEL RIO", &PersonName{"PEDRO", "SOTO", "DEL RIO"}},
+		{"JUAN DE SOTO", &PersonName{"JUAN", "DE SOTO", ""}},
+		{"ANA ROJAS", &PersonName{"ANA", "ROJAS", ""}},
+		{"ANA", nil},
+	}
+	for _, tt := range tests {
+		if got := SplitPersonName(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitPersonName(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCitizen_Classify(t *testing.T) {
+	person := &Citizen{Rut: pkg.MustParseRUT("41.399.675-9"), Name: "ANA ROJAS"}
+	person.Classify()
+	if person.Kind != KindPerson || person.PersonName == nil {
+		t.Errorf("Classify() person = %+v", person)
+	}
+	company := &Citizen{Rut: pkg.MustParseRUT("79.517.385-4"), Name: "SERVICIOS DEL NORTE"}
+	company.Classify()
+	if company.Kind != KindCompany || company.PersonName != nil {
+		t.Errorf("Classify() company = %+v", company)
+	}
+	unknown := &Citizen{Rut: pkg.RUT{Number: 1, DV: "2"}, Name: "ANA ROJAS"}
+	unknown.Classify()
+	if unknown.Kind != KindUnknown {
+		t.Errorf("Classify() unknown = %+v", unknown)
+	}
+	// the RUT range wins over a legal form read in the name
+	personSA := &Citizen{Rut: pkg.MustParseRUT("41.399.675-9"), Name: "JUAN PEREZ SA"}
+	personSA.Classify()
+	if personSA.Kind != KindPerson || personSA.LegalForm != "" {
+		t.Errorf("Classify() person with SA = %+v", personSA)
+	}
+	unknownSpA := &Citizen{Rut: pkg.RUT{Number: 1, DV: "2"}, Name: "SERVICIOS DEL NORTE SPA"}
+	unknownSpA.Classify()
+	if unknownSpA.Kind != KindCompany || unknownSpA.LegalForm != LegalFormSpA {
+		t.Errorf("Classify() company without a valid RUT = %+v", unknownSpA)
+	}
+}
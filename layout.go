@@ -0,0 +1,81 @@
+package gosii
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	// xpathRazonSocialLabel is the label right before the name.
+	xpathRazonSocialLabel = "html body div div:nth-child(3)"
+
+	landmarkNameLabel        = "RAZON SOCIAL"
+	landmarkActivitiesHeader = "ACTIVIDADES"
+	landmarkCodeHeader       = "CODIGO"
+	// landmarkObservationsHeader is the header of the observations table, which is the
+	// only table of the taxpayers without activities.
+	landmarkObservationsHeader = "OBSERVACIONES"
+)
+
+var ErrUnexpectedLayout = errors.New("unexpected SII page layout")
+var ErrSelfCheck = errors.New("self-check failed")
+
+// LayoutError is returned when the page of the SII lacks the landmarks the parser relies on,
+// which means the SII changed the page and the parser must be updated.
+// It matches ErrUnexpectedLayout with errors.Is.
+type LayoutError struct {
+	// Missing describes each missing landmark.
+	Missing []string
+}
+
+func (e *LayoutError) Error() string {
+	return fmt.Sprintf("%s: missing %s", ErrUnexpectedLayout, strings.Join(e.Missing, "; "))
+}
+
+func (e *LayoutError) Is(target error) bool {
+	return target == ErrUnexpectedLayout
+}
+
+// checkLayout validates the landmarks around the positional selectors: the label of the
+// name, and the headers of the activities table when there is one.
+func checkLayout(doc *goquery.Document) error {
+	var missing []string
+	label := NormalizeName(doc.Find(xpathRazonSocialLabel).Text())
+	if !strings.Contains(label, landmarkNameLabel) {
+		missing = append(missing, fmt.Sprintf("name label %q (found %q)", "Nombre o Razón Social", label))
+	}
+	rows := doc.Find(xpathActivities)
+	if rows.Length() > 0 {
+		header := rows.First()
+		activities := NormalizeName(header.Find("th:nth-child(1)").Text())
+		code := NormalizeName(header.Find("th:nth-child(2)").Text())
+		if activities != landmarkObservationsHeader &&
+			(activities != landmarkActivitiesHeader || code != landmarkCodeHeader) {
+			missing = append(missing, fmt.Sprintf("activities table headers %q, %q (found %q, %q)",
+				"Actividades", "Código", activities, code))
+		}
+	}
+	if len(missing) > 0 {
+		return &LayoutError{Missing: missing}
+	}
+	return nil
+}
+
+// SelfCheck looks up a RUT whose name is known and checks that the client still returns it,
+// comparing the normalized names (see NormalizeName). It is meant to be run periodically as
+// a canary of the parser: an error wrapping ErrSelfCheck (and ErrUnexpectedLayout if the
+// page changed) means the lookups cannot be trusted.
+func (c *SIIClient) SelfCheck(ctx context.Context, knownRUT, expectedName string) error {
+	ctz, _, err := c.GetNameByRUTContext(ctx, knownRUT)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrSelfCheck, knownRUT, err)
+	}
+	if NormalizeName(ctz.Name) != NormalizeName(expectedName) {
+		return fmt.Errorf("%w: %s: got name %q, want %q", ErrSelfCheck, knownRUT, ctz.Name, expectedName)
+	}
+	return nil
+}
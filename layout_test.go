@@ -0,0 +1,60 @@
+package gosii
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseLayout(t *testing.T) {
+	data, err := os.ReadFile("parsertest/testdata/company.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := decodeBody(data, "")
+	tests := []struct {
+		name    string
+		html    string
+		wantErr error
+		missing string
+	}{
+		{"ok", page, nil, ""},
+		{"not found", strings.Replace(page, "COMERCIAL PIÑA SPA", "**", 1), ErrNotFound, ""},
+		{"captcha", "<html><body>Por favor reingrese Captcha</body></html>", ErrCaptcha, ""},
+		{"maintenance page", "<html><body><div>Servicio no disponible</div></body></html>", ErrUnexpectedLayout, "name label"},
+		{"name moved", strings.Replace(page, "<br>\n<div style=\"width:200px\">", "<div style=\"width:200px\">", 1), ErrUnexpectedLayout, "name label"},
+		{"columns swapped", strings.Replace(page, "<th><font>Actividades</font></th>\n<th><font>Código</font></th>",
+			"<th><font>Código</font></th>\n<th><font>Actividades</font></th>", 1), ErrUnexpectedLayout, "activities table"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DefaultParser().Parse(tt.html)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DefaultParser().Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			var layoutErr *LayoutError
+			if tt.missing != "" && (!errors.As(err, &layoutErr) || !strings.Contains(layoutErr.Error(), tt.missing)) {
+				t.Errorf("DefaultParser().Parse() error = %v, want missing %s", err, tt.missing)
+			}
+		})
+	}
+}
+
+func TestSelfCheck(t *testing.T) {
+	ctx := context.Background()
+	ok := newFakeClient(nil, newFakeSII("COMERCIAL PI\xd1A SPA"))
+	if err := ok.SelfCheck(ctx, "79517385-4", "Comercial Pina SpA"); err != nil {
+		t.Errorf("SelfCheck() error = %v", err)
+	}
+	wrong := newFakeClient(nil, newFakeSII("OTRA EMPRESA SPA"))
+	if err := wrong.SelfCheck(ctx, "79517385-4", "COMERCIAL PIÑA SPA"); !errors.Is(err, ErrSelfCheck) {
+		t.Errorf("SelfCheck() error = %v, want ErrSelfCheck", err)
+	}
+	broken := newFakeClient(nil, &fakeSII{page: []byte("<html><body><div>Nuevo diseño</div></body></html>")})
+	err := broken.SelfCheck(ctx, "79517385-4", "COMERCIAL PIÑA SPA")
+	if !errors.Is(err, ErrSelfCheck) || !errors.Is(err, ErrUnexpectedLayout) {
+		t.Errorf("SelfCheck() error = %v, want ErrSelfCheck and ErrUnexpectedLayout", err)
+	}
+}
@@ -0,0 +1,182 @@
+package gosii
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority is the class of a lookup for the Limiter. Higher priorities go first.
+type Priority int
+
+const (
+	// PriorityBatch is for background jobs, e.g. a nightly enrichment.
+	PriorityBatch Priority = iota
+	// PriorityNormal is the priority of the lookups without one.
+	PriorityNormal
+	// PriorityInteractive is for a user waiting for the answer.
+	PriorityInteractive
+
+	numPriorities = int(PriorityInteractive) + 1
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityBatch:
+		return "batch"
+	case PriorityInteractive:
+		return "interactive"
+	default:
+		return "normal"
+	}
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx that sets the priority of the lookups made with it.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority set with WithPriority, or PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok && p >= PriorityBatch && p <= PriorityInteractive {
+		return p
+	}
+	return PriorityNormal
+}
+
+type LimiterOpts struct {
+	// MaxConcurrent is the maximum number of lookups to the SII at the same time.
+	// Defaults to 1.
+	MaxConcurrent int
+	// ClassLimits caps the lookups of a priority at the same time, e.g. to keep a slot
+	// free for the interactive ones. A priority without a cap can use every slot.
+	ClassLimits map[Priority]int
+	// MinInterval is the minimum time between the start of two lookups.
+	MinInterval time.Duration
+}
+
+// Limiter bounds the lookups to the SII (see Opts.Limiter). The queued lookups are started by
+// priority, and in order of arrival within a priority. A Limiter can be shared by several
+// clients to share the budget.
+type Limiter struct {
+	opts      LimiterOpts
+	mutex     sync.Mutex
+	running   int
+	byClass   [numPriorities]int
+	queues    [numPriorities][]*limiterWaiter
+	nextStart time.Time
+}
+
+type limiterWaiter struct {
+	priority Priority
+	ready    chan struct{}
+	granted  bool
+}
+
+func NewLimiter(opts LimiterOpts) *Limiter {
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = 1
+	}
+	return &Limiter{opts: opts}
+}
+
+// Acquire waits for a slot for a lookup with the given priority, or until ctx is done.
+// It returns the function to release the slot and the time spent waiting. A priority out
+// of range is clamped to PriorityBatch or PriorityInteractive.
+func (l *Limiter) Acquire(ctx context.Context, priority Priority) (func(), time.Duration, error) {
+	start := time.Now()
+	if priority < PriorityBatch {
+		priority = PriorityBatch
+	} else if priority > PriorityInteractive {
+		priority = PriorityInteractive
+	}
+	w := &limiterWaiter{priority: priority, ready: make(chan struct{})}
+	l.mutex.Lock()
+	l.queues[priority] = append(l.queues[priority], w)
+	l.dispatch()
+	l.mutex.Unlock()
+
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		l.mutex.Lock()
+		if w.granted {
+			l.releaseLocked(priority)
+		} else {
+			l.remove(w)
+		}
+		l.mutex.Unlock()
+		return nil, time.Since(start), ctx.Err()
+	}
+
+	if l.opts.MinInterval > 0 {
+		l.mutex.Lock()
+		startAt := l.nextStart
+		if now := time.Now(); startAt.Before(now) {
+			startAt = now
+		}
+		l.nextStart = startAt.Add(l.opts.MinInterval)
+		l.mutex.Unlock()
+		if err := sleepContext(ctx, time.Until(startAt)); err != nil {
+			l.release(priority)
+			return nil, time.Since(start), err
+		}
+	}
+	var once sync.Once
+	return func() { once.Do(func() { l.release(priority) }) }, time.Since(start), nil
+}
+
+// dispatch starts the queued lookups while there are free slots. The caller must hold
+// the mutex.
+func (l *Limiter) dispatch() {
+	for l.running < l.opts.MaxConcurrent {
+		w := l.next()
+		if w == nil {
+			return
+		}
+		l.running++
+		l.byClass[w.priority]++
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+// next pops the first waiter of the highest priority that is under its cap.
+func (l *Limiter) next() *limiterWaiter {
+	for p := numPriorities - 1; p >= 0; p-- {
+		if len(l.queues[p]) == 0 {
+			continue
+		}
+		if limit, ok := l.opts.ClassLimits[Priority(p)]; ok && l.byClass[p] >= limit {
+			continue
+		}
+		w := l.queues[p][0]
+		l.queues[p] = l.queues[p][1:]
+		return w
+	}
+	return nil
+}
+
+func (l *Limiter) remove(w *limiterWaiter) {
+	queue := l.queues[w.priority]
+	for i := range queue {
+		if queue[i] == w {
+			l.queues[w.priority] = append(queue[:i], queue[i+1:]...)
+			return
+		}
+	}
+}
+
+func (l *Limiter) release(priority Priority) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.releaseLocked(priority)
+}
+
+func (l *Limiter) releaseLocked(priority Priority) {
+	l.running--
+	l.byClass[priority]--
+	l.dispatch()
+}
@@ -0,0 +1,175 @@
+package gosii
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+// waitQueued waits until the limiter has n queued lookups.
+func waitQueued(t *testing.T, l *Limiter, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		l.mutex.Lock()
+		queued := 0
+		for _, q := range l.queues {
+			queued += len(q)
+		}
+		l.mutex.Unlock()
+		if queued == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("queued = %d, want %d", queued, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLimiter_Priority(t *testing.T) {
+	l := NewLimiter(LimiterOpts{MaxConcurrent: 1})
+	release, _, err := l.Acquire(context.Background(), PriorityBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mutex sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	enqueue := func(name string, priority Priority, queued int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, _, err := l.Acquire(context.Background(), priority)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mutex.Lock()
+			order = append(order, name)
+			mutex.Unlock()
+			release()
+		}()
+		waitQueued(t, l, queued)
+	}
+	enqueue("batch 1", PriorityBatch, 1)
+	enqueue("batch 2", PriorityBatch, 2)
+	enqueue("normal", PriorityNormal, 3)
+	enqueue("interactive", PriorityInteractive, 4)
+	release()
+	wg.Wait()
+
+	want := []string{"interactive", "normal", "batch 1", "batch 2"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestLimiter_ClassLimits(t *testing.T) {
+	l := NewLimiter(LimiterOpts{MaxConcurrent: 2, ClassLimits: map[Priority]int{PriorityBatch: 1}})
+	release, _, err := l.Acquire(context.Background(), PriorityBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := l.Acquire(ctx, PriorityBatch); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire(batch) = %v, want the batch class capped", err)
+	}
+	interactive, _, err := l.Acquire(context.Background(), PriorityInteractive)
+	if err != nil {
+		t.Fatalf("Acquire(interactive) = %v", err)
+	}
+	interactive()
+	waitQueued(t, l, 0)
+}
+
+func TestLimiter_MinInterval(t *testing.T) {
+	l := NewLimiter(LimiterOpts{MaxConcurrent: 2, MinInterval: 50 * time.Millisecond})
+	first, _, err := l.Acquire(context.Background(), PriorityNormal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first()
+	second, wait, err := l.Acquire(context.Background(), PriorityNormal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second()
+	if wait < 40*time.Millisecond {
+		t.Errorf("wait = %v, want about 50ms", wait)
+	}
+}
+
+func TestPriorityFromContext(t *testing.T) {
+	if p := PriorityFromContext(context.Background()); p != PriorityNormal {
+		t.Errorf("PriorityFromContext() = %v, want normal", p)
+	}
+	ctx := WithPriority(context.Background(), PriorityInteractive)
+	if p := PriorityFromContext(ctx); p != PriorityInteractive {
+		t.Errorf("PriorityFromContext() = %v, want interactive", p)
+	}
+}
+
+func TestClient_QueueWait(t *testing.T) {
+	limiter := NewLimiter(LimiterOpts{MaxConcurrent: 1})
+	client := newFakeClient(&Opts{Limiter: limiter}, newFakeSII("COMERCIAL PINA SPA"))
+	release, _, err := limiter.Acquire(context.Background(), PriorityBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(50*time.Millisecond, release)
+
+	ctx := WithPriority(context.Background(), PriorityInteractive)
+	ctz, meta, err := client.GetNameByRUTContext(ctx, "79.517.385-4")
+	if err != nil || ctz.Name != "COMERCIAL PINA SPA" {
+		t.Fatalf("GetNameByRUTContext() = %+v, %v", ctz, err)
+	}
+	if meta.QueueWait < 40*time.Millisecond {
+		t.Errorf("QueueWait = %v, want about 50ms", meta.QueueWait)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	hold, _, _ := limiter.Acquire(context.Background(), PriorityBatch)
+	defer hold()
+	if _, meta, err := client.GetNameByRUTContext(ctx, "79.517.385-4"); !errors.Is(err, context.DeadlineExceeded) || meta.QueueWait == 0 {
+		t.Errorf("GetNameByRUTContext() = %+v, %v, want DeadlineExceeded after waiting", meta, err)
+	}
+}
+
+func TestLimiter_OutOfRangePriority(t *testing.T) {
+	limiter := NewLimiter(LimiterOpts{MaxConcurrent: 1})
+	for _, p := range []Priority{-1, 7} {
+		release, _, err := limiter.Acquire(context.Background(), p)
+		if err != nil {
+			t.Fatalf("Acquire(%d) error = %v", p, err)
+		}
+		release()
+	}
+}
+
+func TestClient_LimiterInvalidRUT(t *testing.T) {
+	limiter := NewLimiter(LimiterOpts{MaxConcurrent: 1})
+	sink := &memorySink{}
+	client := newFakeClient(&Opts{Limiter: limiter, Audit: sink}, newFakeSII("COMERCIAL PINA SPA"))
+	hold, _, _ := limiter.Acquire(context.Background(), PriorityBatch)
+	defer hold()
+	// a malformed RUT neither waits for the limiter nor skips the audit log
+	_, meta, err := client.GetNameByRUTPriority(context.Background(), "79517385-5", PriorityInteractive)
+	if !errors.Is(err, pkg.ErrInvalidDV) || meta == nil {
+		t.Errorf("GetNameByRUTPriority() = %+v, %v, want ErrInvalidDV with metadata", meta, err)
+	}
+	if len(sink.entries) != 1 || sink.entries[0].Rut != "79517385-5" {
+		t.Errorf("audit entries = %+v, want the invalid lookup", sink.entries)
+	}
+}
@@ -0,0 +1,27 @@
+package gosii
+
+import "github.com/Eitol/gosii/pkg"
+
+// NameForms holds the name as returned by the SII and its normalized form.
+type NameForms struct {
+	Original   string `json:"original"`
+	Normalized string `json:"normalized"`
+}
+
+// NameForms returns the original name of the citizen and its normalized form,
+// which is suitable for matching against other records.
+//
+// Example: "MIGUEL JUAN SEBASTIÁN PÉREZ ÑÚÑEZ" -> "JOSE MIGUEL PEREZ NUNEZ"
+func (c *Citizen) NameForms() NameForms {
+	return NameForms{
+		Original:   c.Name,
+		Normalized: NormalizeName(c.Name),
+	}
+}
+
+// NormalizeName converts a name to upper case, removes the accents (Ñ becomes N),
+// drops dots and apostrophes, replaces the rest of the punctuation with spaces and
+// collapses the whitespace.
+func NormalizeName(name string) string {
+	return pkg.NormalizeText(name)
+}
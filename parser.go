@@ -0,0 +1,153 @@
+package gosii
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ResponseParser extracts the citizen from the page returned by the SII. Set Opts.Parser to
+// replace the default one, e.g. to ship a fix when the SII changes the page; the parsertest
+// package has the fixtures to test an implementation.
+type ResponseParser interface {
+	// Version identifies the parser; it is reported in RequestMetadata.ParserVersion.
+	Version() string
+	// Parse returns the name and activities of the citizen in the page, already decoded to
+	// UTF-8. It returns ErrNotFound if the RUT does not exist, ErrCaptcha if the captcha was
+	// rejected, and an error matching ErrUnexpectedLayout if the page is not understood.
+	Parse(html string) (*Citizen, error)
+}
+
+// DefaultParser returns the parser used when Opts.Parser is nil.
+func DefaultParser() ResponseParser {
+	return parserV1{}
+}
+
+// parserV1 finds the name and the activities by their position in the page.
+type parserV1 struct{}
+
+func (parserV1) Version() string {
+	return "v1"
+}
+
+func (parserV1) Parse(html string) (*Citizen, error) {
+	ctz, err := parseV1(html)
+	if err != nil && !errors.Is(err, ErrUnexpectedLayout) && strings.Contains(html, "**") {
+		return nil, ErrNotFound
+	}
+	return ctz, err
+}
+
+func parseV1(html string) (*Citizen, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(html, "Por favor reingrese Captcha") {
+		return nil, ErrCaptcha
+	}
+
+	razonSocial := strings.TrimSpace(doc.Find(xpathRazonSocial).Text())
+	if razonSocial == "**" {
+		return nil, ErrNotFound
+	}
+	if err := checkLayout(doc); err != nil {
+		return nil, err
+	}
+	if razonSocial == "" {
+		return nil, ErrNotFound
+	}
+	var actividades []CommercialActivity
+
+	doc.Find(xpathActivities).Each(func(i int, s *goquery.Selection) {
+		if i > 0 {
+			nombre := strings.TrimSpace(s.Find("td:nth-child(1) font").Text())
+			codigo := s.Find("td:nth-child(2) font").Text()
+			var codeInt int
+			codeInt, err = strconv.Atoi(codigo)
+			if err != nil {
+				return
+			}
+			if codeInt > 1970 && codeInt <= time.Now().Year() {
+				return
+			}
+			actividades = append(actividades, CommercialActivity{
+				Code: codigo,
+				Name: nombre,
+			})
+		}
+	})
+
+	ctz := &Citizen{
+		Name:         razonSocial,
+		Activities:   actividades,
+		Observations: parseObservations(doc),
+	}
+	ctz.BusinessEnded, ctz.EndDate = parseBusinessEnd(doc)
+	return ctz, nil
+}
+
+// parseObservations reads the rows of the observations table: the text and, in the
+// second column, the date.
+func parseObservations(doc *goquery.Document) []Observation {
+	var observations []Observation
+	doc.Find(xpathTables).Each(func(_ int, table *goquery.Selection) {
+		rows := table.Find("tr")
+		if NormalizeName(rows.First().Find("th:nth-child(1)").Text()) != landmarkObservationsHeader {
+			return
+		}
+		rows.Slice(1, rows.Length()).Each(func(_ int, row *goquery.Selection) {
+			text := strings.TrimSpace(row.Find("td:nth-child(1)").Text())
+			if text == "" {
+				return
+			}
+			observations = append(observations, Observation{
+				Text: text,
+				Date: parseSIIDate(row.Find("td:nth-child(2)").Text()),
+			})
+		})
+	})
+	return observations
+}
+
+// parseBusinessEnd looks for the término de giro lines, e.g.
+// "Contribuyente presenta Término de Giro: SI" and "Fecha de Término de Giro: 31-12-2024".
+func parseBusinessEnd(doc *goquery.Document) (bool, *time.Time) {
+	ended := false
+	var endDate *time.Time
+	doc.Find("span").Each(func(_ int, s *goquery.Selection) {
+		text := NormalizeName(s.Text())
+		if !strings.Contains(text, "TERMINO DE GIRO") {
+			return
+		}
+		if date := parseSIIDate(s.Text()); date != nil {
+			ended = true
+			endDate = date
+			return
+		}
+		if strings.HasSuffix(text, " SI") {
+			ended = true
+		}
+	})
+	return ended, endDate
+}
+
+var siiDateRegex = regexp.MustCompile(`\b(\d{2})-(\d{2})-(\d{4})\b`)
+
+// parseSIIDate returns the first date in the "02-01-2006" format found in text, or nil.
+func parseSIIDate(text string) *time.Time {
+	match := siiDateRegex.FindString(text)
+	if match == "" {
+		return nil
+	}
+	date, err := time.Parse("02-01-2006", match)
+	if err != nil {
+		return nil
+	}
+	return &date
+}
@@ -0,0 +1,41 @@
+package gosii_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/parsertest"
+)
+
+func TestDefaultParser(t *testing.T) {
+	parsertest.Run(t, gosii.DefaultParser())
+}
+
+// FuzzParse checks that the default parser never panics and only fails with the
+// documented errors.
+func FuzzParse(f *testing.F) {
+	for _, tc := range parsertest.Cases() {
+		body, err := parsertest.Fixture(tc.File)
+		if err != nil {
+			f.Fatal(err)
+		}
+		// the fixtures are in ISO-8859-1, which is also a good input
+		f.Add(string(body))
+	}
+	f.Add("")
+	f.Add("<html><body><div><div></div><div></div><div>Razón Social</div><div>X</div><table><tr><th>Actividades</th><th>Código</th></tr><tr><td><font>A</font></td><td><font>x</font></td></tr></table></div></body></html>")
+	parser := gosii.DefaultParser()
+	f.Fuzz(func(t *testing.T, html string) {
+		ctz, err := parser.Parse(html)
+		if err != nil {
+			if !errors.Is(err, gosii.ErrNotFound) && !errors.Is(err, gosii.ErrCaptcha) && !errors.Is(err, gosii.ErrUnexpectedLayout) {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			return
+		}
+		if ctz == nil || ctz.Name == "" {
+			t.Fatalf("Parse() = %+v, want a name", ctz)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+// Package parsertest has fixture pages of the SII and the results expected from them,
+// to test any gosii.ResponseParser:
+//
+//	func TestMyParser(t *testing.T) {
+//		parsertest.Run(t, &MyParser{})
+//	}
+package parsertest
+
+import (
+	"embed"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Eitol/gosii"
+)
+
+//go:embed testdata/*.html
+var fixtures embed.FS
+
+// Case is a fixture page and the result expected from it.
+type Case struct {
+	Name string
+	// File is the name of the fixture in testdata. The pages are encoded in ISO-8859-1,
+	// as served by the SII.
+	File string
+	Rut  string
+	// Want has the expected name and activities when WantErr is nil.
+	Want    *gosii.Citizen
+	WantErr error
+}
+
+// Cases returns the fixtures. The names in them are made up, and the RUTs come from
+// pkg.Generate(17).
+func Cases() []Case {
+	return []Case{
+		{
+			Name: "company",
+			File: "company.html",
+			Rut:  "79517385-4",
+			Want: &gosii.Citizen{
+				Name: "COMERCIAL PIÑA SPA",
+				Activities: []gosii.CommercialActivity{
+					{Code: "479100", Name: "VENTA AL POR MENOR POR CORREO, POR INTERNET Y VIA TELEFONICA"},
+					{Code: "469000", Name: "VENTA AL POR MAYOR NO ESPECIALIZADA"},
+				},
+			},
+		},
+		{
+			Name: "person without activities",
+			File: "person.html",
+			Rut:  "41399675-9",
+			Want: &gosii.Citizen{Name: "ANA MARÍA ROJAS DEL CAMPO"},
+		},
+		{
+			Name: "business ended",
+			File: "ended.html",
+			Rut:  "79862956-5",
+			Want: &gosii.Citizen{
+				Name: "DISTRIBUIDORA LOS AROMOS LIMITADA",
+				Activities: []gosii.CommercialActivity{
+					{Code: "479100", Name: "VENTA AL POR MENOR POR CORREO, POR INTERNET Y VIA TELEFONICA"},
+					{Code: "469000", Name: "VENTA AL POR MAYOR NO ESPECIALIZADA"},
+				},
+				BusinessEnded: true,
+				EndDate:       date(2024, time.December, 31),
+			},
+		},
+		{
+			Name: "business ended without date",
+			File: "ended_without_date.html",
+			Rut:  "79622705-2",
+			Want: &gosii.Citizen{
+				Name: "PANADERIA EL TRIGAL SPA",
+				Activities: []gosii.CommercialActivity{
+					{Code: "479100", Name: "VENTA AL POR MENOR POR CORREO, POR INTERNET Y VIA TELEFONICA"},
+					{Code: "469000", Name: "VENTA AL POR MAYOR NO ESPECIALIZADA"},
+				},
+				BusinessEnded: true,
+			},
+		},
+		{
+			Name: "observations",
+			File: "observations.html",
+			Rut:  "42266856-K",
+			Want: &gosii.Citizen{
+				Name: "PEDRO PÉREZ SOTO",
+				Observations: []gosii.Observation{
+					{Text: "Contribuyente presenta anotaciones vigentes. Debe acudir a la unidad del SII.", Date: date(2025, time.May, 12)},
+					{Text: "Domicilio no ubicado"},
+				},
+			},
+		},
+		{Name: "not found", File: "not_found.html", Rut: "42533552-9", WantErr: gosii.ErrNotFound},
+		{Name: "captcha rejected", File: "captcha.html", Rut: "41399675-9", WantErr: gosii.ErrCaptcha},
+		{Name: "unknown page", File: "maintenance.html", Rut: "41399675-9", WantErr: gosii.ErrUnexpectedLayout},
+	}
+}
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+// Fixture returns the content of a fixture page.
+func Fixture(file string) ([]byte, error) {
+	return fixtures.ReadFile("testdata/" + file)
+}
+
+// Run parses every fixture with parser, through gosii.ParseRawResponse, and checks the
+// results against Cases.
+func Run(t *testing.T, parser gosii.ResponseParser) {
+	t.Helper()
+	for _, tc := range Cases() {
+		t.Run(tc.Name, func(t *testing.T) {
+			body, err := Fixture(tc.File)
+			if err != nil {
+				t.Fatal(err)
+			}
+			raw := &gosii.RawResponse{
+				Rut:    tc.Rut,
+				Body:   body,
+				Header: http.Header{"Content-Type": {"text/html"}},
+			}
+			got, err := gosii.ParseRawResponse(raw, &gosii.Opts{Parser: parser})
+			if tc.WantErr != nil {
+				if !errors.Is(err, tc.WantErr) {
+					t.Fatalf("Parse() error = %v, want %v", err, tc.WantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got.Rut.String() != tc.Rut || got.Name != tc.Want.Name {
+				t.Errorf("Parse() = %q %q, want %q %q", got.Rut, got.Name, tc.Rut, tc.Want.Name)
+			}
+			if len(got.Activities) != len(tc.Want.Activities) ||
+				(len(got.Activities) > 0 && !reflect.DeepEqual(got.Activities, tc.Want.Activities)) {
+				t.Errorf("Parse() activities = %+v, want %+v", got.Activities, tc.Want.Activities)
+			}
+			if len(got.Observations) != len(tc.Want.Observations) ||
+				(len(got.Observations) > 0 && !reflect.DeepEqual(got.Observations, tc.Want.Observations)) {
+				t.Errorf("Parse() observations = %+v, want %+v", got.Observations, tc.Want.Observations)
+			}
+			if got.BusinessEnded != tc.Want.BusinessEnded || !reflect.DeepEqual(got.EndDate, tc.Want.EndDate) {
+				t.Errorf("Parse() business ended = %v %v, want %v %v",
+					got.BusinessEnded, got.EndDate, tc.Want.BusinessEnded, tc.Want.EndDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match is a RUT found in a text.
+type Match struct {
+	// Start and End are the byte offsets of the RUT in the text, without its prefix.
+	Start int `json:"start"`
+	End   int `json:"end"`
+	// Text is the RUT as written in the text.
+	Text string `json:"text"`
+	// RUT is the RUT read from Text, after fixing the OCR confusions.
+	RUT RUT `json:"rut"`
+	// Valid reports whether the check digit matches the number.
+	Valid bool `json:"valid"`
+}
+
+// UnmarshalJSON reads a Match written by json.Marshal. Unlike RUT.UnmarshalJSON, it
+// accepts a RUT with a wrong check digit, since the invalid matches of FindAll have one.
+func (m *Match) UnmarshalJSON(data []byte) error {
+	type match Match
+	var v struct {
+		match
+		RUT *string `json:"rut"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*m = Match(v.match)
+	m.RUT = RUT{}
+	if v.RUT == nil || *v.RUT == "" {
+		return nil
+	}
+	rut, err := ParseRUT(*v.RUT)
+	if err != nil && !errors.Is(err, ErrInvalidDV) {
+		return err
+	}
+	m.RUT = rut
+	return nil
+}
+
+const (
+	// findDigit also accepts the letters that OCR confuses with digits.
+	findDigit = `[0-9OoIl]`
+	findSep   = `[., ]`
+	findDash  = `\s?[-‐–]\s?`
+	findDV    = `[0-9kKOo]`
+	// findPrefix is "RUT", "R.U.T.", "RUN" or "Rol Único Tributario", with an optional
+	// "N°" and colon.
+	findPrefix = `(?i:(?:R\.?U\.?[TN]\.?|ROL [UÚ]NICO TRIBUTARIO)\s*(?:N[°º.]?\s*)?:?\s*)`
+)
+
+var findRegex = regexp.MustCompile(
+	// "12.345.678-9", "12 345 678 9", "12,345.678-9" (with or without prefix)
+	`(?:` + findPrefix + `)?([1-9]` + findDigit + `?` + findSep + findDigit + `{3}` + findSep + findDigit + `{3}(?:` + findDash + `|\s)?` + findDV +
+		// "12345678-9"
+		`|[1-9]` + findDigit + `{6,7}` + findDash + findDV + `)` +
+		// "RUT: 123456789", only with a prefix
+		`|` + findPrefix + `([1-9]` + findDigit + `{6,7}` + findDV + `)`)
+
+var ocrFixes = strings.NewReplacer("O", "0", "o", "0", "I", "1", "l", "1")
+
+// FindAll finds the RUTs written in a text in the usual formats ("12.345.678-9",
+// "12345678-9", "12 345 678 9", ...), also after a "RUT:" prefix ("RUT: 123456789"),
+// and tolerates the letters that OCR confuses with digits ("79.5l7.385-4").
+//
+// The check digit of each RUT is validated with GetRutDv; the candidates with a wrong one
+// are returned too, with Valid set to false.
+func FindAll(text string) []Match {
+	var matches []Match
+	for _, loc := range findRegex.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[2], loc[3]
+		if start < 0 {
+			start, end = loc[4], loc[5]
+		}
+		if !isBoundary(text, loc[0], end) {
+			continue
+		}
+		rut, ok := readRUT(text[start:end])
+		if !ok {
+			continue
+		}
+		matches = append(matches, Match{
+			Start: start,
+			End:   end,
+			Text:  text[start:end],
+			RUT:   rut,
+			Valid: rut.IsValid(),
+		})
+	}
+	return matches
+}
+
+// isBoundary reports whether text[start:end] is not part of a longer word or number.
+func isBoundary(text string, start, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && isWordRune(before) {
+		return false
+	}
+	if after, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && isWordRune(after) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// readRUT reads a RUT found by findRegex.
+func readRUT(s string) (RUT, bool) {
+	var sb strings.Builder
+	for _, r := range s {
+		if r < utf8.RuneSelf && (r == 'k' || r == 'K' || r == 'O' || r == 'o' || r == 'I' || r == 'l' || (r >= '0' && r <= '9')) {
+			sb.WriteRune(r)
+		}
+	}
+	clean := sb.String()
+	dv := strings.ToUpper(clean[len(clean)-1:])
+	if dv != "K" {
+		dv = ocrFixes.Replace(dv)
+	}
+	number, err := strconv.Atoi(ocrFixes.Replace(clean[:len(clean)-1]))
+	if err != nil || number <= 0 {
+		return RUT{}, false
+	}
+	return RUT{Number: number, DV: dv}, true
+}
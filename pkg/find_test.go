@@ -0,0 +1,56 @@
+package pkg
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	text := "Cliente RUT: 79.517.385-4 reclama factura del R.U.T. N° 795173854.\n" +
+		"OCR: 79.5l7.385-4, 41817975-9, 41 817 975 9, 41.3O9.9O8-0 y rut 41.817.975-K (mal).\n" +
+		"Teléfono +56 9 1234 5678, folio 123456789, código 779517385-4."
+	tests := []struct {
+		text  string
+		rut   string
+		valid bool
+	}{
+		{"79.517.385-4", "79517385-4", true},
+		{"795173854", "79517385-4", true},
+		{"79.5l7.385-4", "79517385-4", true},
+		{"41817975-9", "41817975-9", true},
+		{"41 817 975 9", "41817975-9", true},
+		{"41.3O9.9O8-0", "41309908-0", true},
+		{"41.817.975-K", "41817975-K", false},
+	}
+	matches := FindAll(text)
+	if len(matches) != len(tests) {
+		t.Fatalf("FindAll() = %+v, want %d matches", matches, len(tests))
+	}
+	for i, tt := range tests {
+		m := matches[i]
+		if m.Text != tt.text || m.RUT.String() != tt.rut || m.Valid != tt.valid || text[m.Start:m.End] != tt.text {
+			t.Errorf("FindAll()[%d] = %+v, want %q -> %s (valid %v)", i, m, tt.text, tt.rut, tt.valid)
+		}
+	}
+}
+
+func TestFindAll_Nothing(t *testing.T) {
+	for _, text := range []string{"", "sin datos", "pedido 12345678", "12.345.678", "lol ll-1"} {
+		if matches := FindAll(text); len(matches) != 0 {
+			t.Errorf("FindAll(%q) = %+v, want none", text, matches)
+		}
+	}
+}
+
+func TestFindAll_RoundTrip(t *testing.T) {
+	matches := FindAll("41.817.975-K y 41817975-9")
+	data, err := json.Marshal(matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back []Match
+	if err := json.Unmarshal(data, &back); err != nil || !reflect.DeepEqual(back, matches) {
+		t.Errorf("json round trip = %+v, %v, want %+v", back, err, matches)
+	}
+}
@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// The RUTs are drawn from bands that the SII has not assigned: as of 2026 the RUNs of natural
+// persons go up to about 30 million, and the new legal entities get numbers below 79 million.
+const (
+	// personMinRUT is the lowest number generated for natural persons; they are drawn
+	// from [personMinRUT, companyMinRUT).
+	personMinRUT = 40_000_000
+	// generatedCompanyMinRUT and generatedCompanyMaxRUT (exclusive) bound the numbers
+	// generated for legal entities.
+	generatedCompanyMinRUT = 79_500_000
+	generatedCompanyMaxRUT = 80_000_000
+	// maxRUT is the highest number that ParseRUT accepts.
+	maxRUT = 99_999_999
+)
+
+// Generator produces RUTs for tests and fixtures, so that they do not need real identifiers.
+// The RUTs are valid but come from ranges that the SII has not assigned (see personMinRUT),
+// so they do not belong to real taxpayers. They depend only on the seed: the same seed
+// always gives the same sequence.
+// A Generator is not safe for concurrent use.
+type Generator struct {
+	rnd *rand.Rand
+}
+
+// Generate returns a Generator seeded with seed.
+//
+//	gen := pkg.Generate(42)
+//	person, company := gen.Person(), gen.Company()
+func Generate(seed int64) *Generator {
+	return &Generator{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// Person returns a valid RUT in the range of the natural persons, above the RUNs issued.
+func (g *Generator) Person() RUT {
+	return withDV(personMinRUT + g.rnd.Intn(companyMinRUT-personMinRUT))
+}
+
+// Company returns a valid RUT in the range of the legal entities, above the RUTs assigned.
+func (g *Generator) Company() RUT {
+	return withDV(generatedCompanyMinRUT + g.rnd.Intn(generatedCompanyMaxRUT-generatedCompanyMinRUT))
+}
+
+// InvalidDV returns a RUT of a person whose check digit is wrong (ParseRUT returns ErrInvalidDV).
+func (g *Generator) InvalidDV() RUT {
+	rut := g.Person()
+	dvs := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "K"}
+	for {
+		dv := dvs[g.rnd.Intn(len(dvs))]
+		if dv != rut.DV {
+			return RUT{Number: rut.Number, DV: dv}
+		}
+	}
+}
+
+// InvalidLength returns a RUT, in the "123456789-0" format, with a number that is too long
+// even if its check digit is right (ParseRUT returns ErrInvalidRUT).
+func (g *Generator) InvalidLength() string {
+	number := maxRUT + 1 + g.rnd.Intn(9*(maxRUT+1))
+	return strconv.Itoa(number) + "-" + withDV(number).DV
+}
+
+func withDV(number int) RUT {
+	return RUT{Number: number, DV: strings.ToUpper(GetRutDv(number))}
+}
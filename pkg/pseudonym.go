@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// pseudonymSize is the number of bytes of the HMAC kept in a pseudonym.
+const pseudonymSize = 12
+
+// Pseudonymizer replaces RUTs with pseudonyms for analytics: the HMAC-SHA256 of the RUT with
+// a secret key. The same RUT and key always give the same pseudonym, so the records of a
+// taxpayer can still be joined, but the RUT cannot be recovered without the key.
+//
+// The pseudonyms generated are kept in a lookup table (see Table) that can be stored apart
+// to reverse them when needed. It is safe for concurrent use.
+type Pseudonymizer struct {
+	key   []byte
+	mutex sync.Mutex
+	table map[string]RUT
+}
+
+func NewPseudonymizer(key []byte) *Pseudonymizer {
+	return &Pseudonymizer{key: append([]byte(nil), key...), table: map[string]RUT{}}
+}
+
+// Pseudonym returns the pseudonym of a RUT, a 24 characters hexadecimal string,
+// and adds it to the lookup table.
+func (p *Pseudonymizer) Pseudonym(rut RUT) string {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(rut.String()))
+	pseudonym := hex.EncodeToString(mac.Sum(nil)[:pseudonymSize])
+	p.mutex.Lock()
+	p.table[pseudonym] = rut
+	p.mutex.Unlock()
+	return pseudonym
+}
+
+// Reverse returns the RUT of a pseudonym from the lookup table.
+func (p *Pseudonymizer) Reverse(pseudonym string) (RUT, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	rut, ok := p.table[pseudonym]
+	return rut, ok
+}
+
+// Table returns a copy of the lookup table, from pseudonym to RUT.
+func (p *Pseudonymizer) Table() map[string]RUT {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	table := make(map[string]RUT, len(p.table))
+	for k, v := range p.table {
+		table[k] = v
+	}
+	return table
+}
+
+// LoadTable adds the entries of a lookup table previously returned by Table.
+func (p *Pseudonymizer) LoadTable(table map[string]RUT) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for k, v := range table {
+		p.table[k] = v
+	}
+}
+
+// Mask returns the RUT in the "12.345.678-9" format with every digit but the last three of
+// the number hidden, and the check digit too: "**.***.678-*".
+func (r RUT) Mask() string {
+	if r.IsZero() {
+		return ""
+	}
+	digits := strconv.Itoa(r.Number)
+	visible := 3
+	if visible > len(digits) {
+		visible = len(digits)
+	}
+	masked := strings.Repeat("*", len(digits)-visible) + digits[len(digits)-visible:]
+	var sb strings.Builder
+	for i, d := range masked {
+		if i > 0 && (len(masked)-i)%3 == 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteRune(d)
+	}
+	return sb.String() + "-*"
+}
@@ -0,0 +1,47 @@
+package pkg
+
+import "testing"
+
+func TestPseudonymizer(t *testing.T) {
+	gen := Generate(7)
+	a, b := gen.Person(), gen.Company()
+	p := NewPseudonymizer([]byte("secret"))
+	pa, pb := p.Pseudonym(a), p.Pseudonym(b)
+	if len(pa) != 24 || pa == pb || pa == a.String() {
+		t.Errorf("Pseudonym() = %q, %q", pa, pb)
+	}
+	if again := NewPseudonymizer([]byte("secret")).Pseudonym(a); again != pa {
+		t.Errorf("Pseudonym() = %q, want the same pseudonym %q for the same key", again, pa)
+	}
+	if other := NewPseudonymizer([]byte("other")).Pseudonym(a); other == pa {
+		t.Errorf("Pseudonym() = %q with another key", other)
+	}
+	if rut, ok := p.Reverse(pa); !ok || rut != a {
+		t.Errorf("Reverse(%q) = %v, %v, want %v", pa, rut, ok, a)
+	}
+
+	restored := NewPseudonymizer([]byte("secret"))
+	if _, ok := restored.Reverse(pb); ok {
+		t.Errorf("Reverse() without the table found the RUT")
+	}
+	restored.LoadTable(p.Table())
+	if rut, ok := restored.Reverse(pb); !ok || rut != b {
+		t.Errorf("Reverse(%q) = %v, %v, want %v", pb, rut, ok, b)
+	}
+}
+
+func TestRUT_Mask(t *testing.T) {
+	tests := map[string]string{
+		"79.517.385-4": "**.***.385-*",
+		"41.817.975-9": "**.***.975-*",
+		"6-K":          "6-*",
+	}
+	for in, want := range tests {
+		if got := MustParseRUT(in).Mask(); got != want {
+			t.Errorf("Mask(%s) = %q, want %q", in, got, want)
+		}
+	}
+	if got := (RUT{}).Mask(); got != "" {
+		t.Errorf("Mask() = %q, want empty", got)
+	}
+}
@@ -1,6 +1,10 @@
 package pkg
 
-import "strconv"
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
 
 func GetRutDv(rut int) string {
 	sum := 0
@@ -25,3 +29,92 @@ func GetRutDv(rut int) string {
 		return strconv.Itoa(11 - mod)
 	}
 }
+
+var ErrInvalidRUT = errors.New("invalid rut")
+var ErrInvalidDV = errors.New("invalid rut check digit")
+
+// companyMinRUT is the lowest RUT number assigned by the SII to legal entities.
+// Natural persons (including foreigners) get numbers below it.
+const companyMinRUT = 50_000_000
+
+// RUT is a Chilean Rol Único Tributario split in its number and check digit (DV).
+type RUT struct {
+	Number int
+	DV     string
+}
+
+// ParseRUT parses a RUT in any of the usual formats: "12345678-9", "12.345.678-9",
+// "123456789" or "12 345 678 9". The check digit is validated.
+func ParseRUT(s string) (RUT, error) {
+	clean := strings.ToUpper(s)
+	clean = strings.NewReplacer(".", "", "-", "", " ", "").Replace(clean)
+	if len(clean) < 2 || len(clean) > 9 {
+		return RUT{}, ErrInvalidRUT
+	}
+	dv := clean[len(clean)-1:]
+	if dv != "K" && (dv[0] < '0' || dv[0] > '9') {
+		return RUT{}, ErrInvalidRUT
+	}
+	for _, c := range clean[:len(clean)-1] {
+		if c < '0' || c > '9' {
+			return RUT{}, ErrInvalidRUT
+		}
+	}
+	number, err := strconv.Atoi(clean[:len(clean)-1])
+	if err != nil || number <= 0 {
+		return RUT{}, ErrInvalidRUT
+	}
+	rut := RUT{Number: number, DV: dv}
+	if !rut.IsValid() {
+		return rut, ErrInvalidDV
+	}
+	return rut, nil
+}
+
+// MustParseRUT is like ParseRUT but panics if the RUT is invalid. It simplifies the
+// initialization of variables and test fixtures.
+func MustParseRUT(s string) RUT {
+	rut, err := ParseRUT(s)
+	if err != nil {
+		panic(`pkg: ParseRUT(` + strconv.Quote(s) + `): ` + err.Error())
+	}
+	return rut
+}
+
+// IsValid reports whether the check digit matches the number.
+func (r RUT) IsValid() bool {
+	return r.Number > 0 && strings.EqualFold(GetRutDv(r.Number), r.DV)
+}
+
+// IsCompany reports whether the number is in the range assigned to legal entities.
+func (r RUT) IsCompany() bool {
+	return r.Number >= companyMinRUT
+}
+
+// String returns the RUT in the "12345678-9" format, or "" for the zero RUT.
+func (r RUT) String() string {
+	if r.IsZero() {
+		return ""
+	}
+	return strconv.Itoa(r.Number) + "-" + r.upperDV()
+}
+
+// Format returns the RUT in the "12.345.678-9" format, or "" for the zero RUT.
+func (r RUT) Format() string {
+	if r.IsZero() {
+		return ""
+	}
+	digits := strconv.Itoa(r.Number)
+	var sb strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteRune(d)
+	}
+	return sb.String() + "-" + r.upperDV()
+}
+
+func (r RUT) upperDV() string {
+	return strings.ToUpper(r.DV)
+}
@@ -0,0 +1,174 @@
+package pkg
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// RUTFormat is a way of writing a RUT.
+type RUTFormat int32
+
+const (
+	// FormatDash is the "12345678-9" format (RUT.String).
+	FormatDash RUTFormat = iota
+	// FormatDotted is the "12.345.678-9" format (RUT.Format).
+	FormatDotted
+	// FormatCompact is the "123456789" format.
+	FormatCompact
+)
+
+// DottedRUT is a RUT marshalled to JSON, text and SQL in the FormatDotted format, for the
+// fields and columns that store it that way. Like RUT, it is unmarshalled from any format.
+type DottedRUT struct {
+	RUT
+}
+
+// CompactRUT is a RUT marshalled to JSON, text and SQL in the FormatCompact format.
+// Like RUT, it is unmarshalled from any format.
+type CompactRUT struct {
+	RUT
+}
+
+// IsZero reports whether the RUT is empty.
+func (r RUT) IsZero() bool {
+	return r.Number == 0 && r.DV == ""
+}
+
+// In returns the RUT in the given format. The zero RUT is the empty string.
+func (r RUT) In(format RUTFormat) string {
+	switch {
+	case r.IsZero():
+		return ""
+	case format == FormatDotted:
+		return r.Format()
+	case format == FormatCompact:
+		return strconv.Itoa(r.Number) + r.upperDV()
+	default:
+		return r.String()
+	}
+}
+
+// parseStored parses a marshalled RUT like ParseRUT, but the empty string is the zero RUT.
+func parseStored(s string) (RUT, error) {
+	if s == "" {
+		return RUT{}, nil
+	}
+	return ParseRUT(s)
+}
+
+// MarshalText writes the RUT in the FormatDash format, like the other marshallers of RUT.
+// Use DottedRUT or CompactRUT to store it in another format.
+func (r RUT) MarshalText() ([]byte, error) {
+	return []byte(r.In(FormatDash)), nil
+}
+
+func (r *RUT) UnmarshalText(text []byte) error {
+	parsed, err := parseStored(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// MarshalJSON writes the RUT as a string in the FormatDash format.
+func (r RUT) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.In(FormatDash))
+}
+
+// UnmarshalJSON reads a RUT from a string in any format, or null. Like ParseRUT, it returns
+// ErrInvalidRUT or ErrInvalidDV if the RUT is malformed or its check digit is wrong.
+func (r *RUT) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRUT, data)
+	}
+	if s == nil {
+		*r = RUT{}
+		return nil
+	}
+	return r.UnmarshalText([]byte(*s))
+}
+
+func (r RUT) MarshalEasyJSON(w *jwriter.Writer) {
+	w.String(r.In(FormatDash))
+}
+
+func (r *RUT) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	if l.IsNull() {
+		l.Skip()
+		*r = RUT{}
+		return
+	}
+	if err := r.UnmarshalText([]byte(l.String())); err != nil {
+		l.AddError(err)
+	}
+}
+
+// Value stores the RUT as a string in the FormatDash format, or NULL for the zero RUT.
+func (r RUT) Value() (driver.Value, error) {
+	return r.value(FormatDash)
+}
+
+func (r RUT) value(format RUTFormat) (driver.Value, error) {
+	if r.IsZero() {
+		return nil, nil
+	}
+	return r.In(format), nil
+}
+
+// Scan reads a RUT stored as a string in any format, or as NULL. Like ParseRUT, it
+// returns ErrInvalidRUT or ErrInvalidDV if the RUT is malformed or its check digit is wrong.
+func (r *RUT) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*r = RUT{}
+		return nil
+	case string:
+		return r.UnmarshalText([]byte(v))
+	case []byte:
+		return r.UnmarshalText(v)
+	case int64:
+		// a compact RUT stored as a number (its check digit can not be K)
+		return r.UnmarshalText([]byte(strconv.FormatInt(v, 10)))
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidRUT, src)
+	}
+}
+
+func (r DottedRUT) MarshalText() ([]byte, error) {
+	return []byte(r.In(FormatDotted)), nil
+}
+
+func (r DottedRUT) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.In(FormatDotted))
+}
+
+func (r DottedRUT) MarshalEasyJSON(w *jwriter.Writer) {
+	w.String(r.In(FormatDotted))
+}
+
+func (r DottedRUT) Value() (driver.Value, error) {
+	return r.value(FormatDotted)
+}
+
+func (r CompactRUT) MarshalText() ([]byte, error) {
+	return []byte(r.In(FormatCompact)), nil
+}
+
+func (r CompactRUT) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.In(FormatCompact))
+}
+
+func (r CompactRUT) MarshalEasyJSON(w *jwriter.Writer) {
+	w.String(r.In(FormatCompact))
+}
+
+func (r CompactRUT) Value() (driver.Value, error) {
+	return r.value(FormatCompact)
+}
@@ -0,0 +1,218 @@
+package pkg
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+func TestGetRutDv(t *testing.T) {
+	tests := []struct {
+		rut  int
+		want string
+	}{
+		{41817975, "9"},
+		{41399675, "9"},
+		{79517385, "4"},
+		{79862956, "5"},
+		{6, "k"},
+		{1, "9"},
+	}
+	for _, tt := range tests {
+		if got := GetRutDv(tt.rut); got != tt.want {
+			t.Errorf("GetRutDv(%d) = %q, want %q", tt.rut, got, tt.want)
+		}
+	}
+}
+
+// FuzzGetRutDv checks the modulus 11 property: the digits of the number weighted
+// 2, 3, ..., 7, 2, 3, ... from the right plus the value of the check digit (K = 10)
+// is a multiple of 11.
+func FuzzGetRutDv(f *testing.F) {
+	f.Add(41817975)
+	f.Add(6)
+	f.Add(99999999)
+	f.Fuzz(func(t *testing.T, rut int) {
+		if rut <= 0 {
+			return
+		}
+		dv := GetRutDv(rut)
+		var value int
+		switch {
+		case dv == "k":
+			value = 10
+		case len(dv) == 1 && dv[0] >= '0' && dv[0] <= '9':
+			value = int(dv[0] - '0')
+		default:
+			t.Fatalf("GetRutDv(%d) = %q, want a digit or k", rut, dv)
+		}
+		digits := strconv.Itoa(rut)
+		sum := 0
+		for i := range digits {
+			weight := 2 + i%6
+			sum += int(digits[len(digits)-1-i]-'0') * weight
+		}
+		if (sum+value)%11 != 0 {
+			t.Errorf("GetRutDv(%d) = %q, does not verify", rut, dv)
+		}
+	})
+}
+
+func TestParseRUT(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{"41.817.975-9", "41817975-9", nil},
+		{"418179759", "41817975-9", nil},
+		{"41 817 975 9", "41817975-9", nil},
+		{"6-k", "6-K", nil},
+		{"41.817.975-K", "", ErrInvalidDV},
+		{"", "", ErrInvalidRUT},
+		{"+41817975-9", "", ErrInvalidRUT},
+		{"1234567890-1", "", ErrInvalidRUT},
+	}
+	for _, tt := range tests {
+		got, err := ParseRUT(tt.in)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseRUT(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("ParseRUT(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// FuzzParseRUT checks that ParseRUT never panics, that the RUTs it accepts are valid and
+// that they survive a round trip through String and Format.
+func FuzzParseRUT(f *testing.F) {
+	for _, seed := range []string{"41.817.975-9", "418179759", "6-k", "79 517 385 4", "-", "..-K", "0-0", "+1-9"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		rut, err := ParseRUT(s)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidRUT) && !errors.Is(err, ErrInvalidDV) {
+				t.Fatalf("ParseRUT(%q) error = %v", s, err)
+			}
+			return
+		}
+		if !rut.IsValid() || rut.Number <= 0 {
+			t.Fatalf("ParseRUT(%q) = %+v, not valid", s, rut)
+		}
+		for _, formatted := range []string{rut.String(), rut.Format(), strings.ToLower(rut.Format())} {
+			again, err := ParseRUT(formatted)
+			if err != nil || again.String() != rut.String() {
+				t.Errorf("ParseRUT(%q) = %v, %v, want %v", formatted, again, err, rut)
+			}
+		}
+	})
+}
+
+func TestRUTEncoding(t *testing.T) {
+	type record struct {
+		Rut      RUT  `json:"rut"`
+		Optional *RUT `json:"optional,omitempty"`
+	}
+	rut := MustParseRUT("79.517.385-4")
+
+	data, err := json.Marshal(record{Rut: rut})
+	if err != nil || string(data) != `{"rut":"79517385-4"}` {
+		t.Errorf("json.Marshal() = %s, %v", data, err)
+	}
+	var decoded record
+	if err := json.Unmarshal([]byte(`{"rut":"79.517.385-4","optional":null}`), &decoded); err != nil || decoded.Rut != rut {
+		t.Errorf("json.Unmarshal() = %+v, %v", decoded, err)
+	}
+	if err := json.Unmarshal([]byte(`{"rut":"79.517.385-3"}`), &decoded); !errors.Is(err, ErrInvalidDV) {
+		t.Errorf("json.Unmarshal() error = %v, want ErrInvalidDV", err)
+	}
+	if err := json.Unmarshal([]byte(`{"rut":"79.517.385-X"}`), &decoded); !errors.Is(err, ErrInvalidRUT) {
+		t.Errorf("json.Unmarshal() error = %v, want ErrInvalidRUT", err)
+	}
+	if err := json.Unmarshal([]byte(`{"rut":79517385}`), &decoded); !errors.Is(err, ErrInvalidRUT) {
+		t.Errorf("json.Unmarshal() error = %v, want ErrInvalidRUT", err)
+	}
+
+	w := &jwriter.Writer{}
+	rut.MarshalEasyJSON(w)
+	var fromEasyJSON RUT
+	fromEasyJSON.UnmarshalEasyJSON(&jlexer.Lexer{Data: w.Buffer.BuildBytes()})
+	if fromEasyJSON != rut {
+		t.Errorf("UnmarshalEasyJSON() = %+v, want %+v", fromEasyJSON, rut)
+	}
+
+	value, err := rut.Value()
+	if err != nil || value != "79517385-4" {
+		t.Errorf("Value() = %v, %v", value, err)
+	}
+	if value, _ := (RUT{}).Value(); value != nil {
+		t.Errorf("Value() = %v, want nil", value)
+	}
+	for _, src := range []any{"79.517.385-4", []byte("795173854"), int64(795173854)} {
+		var scanned RUT
+		if err := scanned.Scan(src); err != nil || scanned != rut {
+			t.Errorf("Scan(%v) = %+v, %v", src, scanned, err)
+		}
+	}
+	var scanned RUT
+	if err := scanned.Scan(nil); err != nil || !scanned.IsZero() {
+		t.Errorf("Scan(nil) = %+v, %v", scanned, err)
+	}
+	if err := scanned.Scan("79517385-3"); !errors.Is(err, ErrInvalidDV) {
+		t.Errorf("Scan() error = %v, want ErrInvalidDV", err)
+	}
+	if err := scanned.Scan(3.14); !errors.Is(err, ErrInvalidRUT) {
+		t.Errorf("Scan(float) error = %v, want ErrInvalidRUT", err)
+	}
+}
+
+func TestRUTFormats(t *testing.T) {
+	type record struct {
+		Dash    RUT        `json:"dash"`
+		Dotted  DottedRUT  `json:"dotted"`
+		Compact CompactRUT `json:"compact"`
+	}
+	rut := MustParseRUT("79517385-4")
+	data, err := json.Marshal(record{Dash: rut, Dotted: DottedRUT{rut}, Compact: CompactRUT{rut}})
+	if want := `{"dash":"79517385-4","dotted":"79.517.385-4","compact":"795173854"}`; err != nil || string(data) != want {
+		t.Errorf("json.Marshal() = %s, %v, want %s", data, err, want)
+	}
+	var back record
+	if err := json.Unmarshal(data, &back); err != nil || back.Dash != rut || back.Dotted.RUT != rut || back.Compact.RUT != rut {
+		t.Errorf("json.Unmarshal() = %+v, %v", back, err)
+	}
+	if err := json.Unmarshal([]byte(`{"dotted":"79.517.385-3"}`), &back); !errors.Is(err, ErrInvalidDV) {
+		t.Errorf("json.Unmarshal() error = %v, want ErrInvalidDV", err)
+	}
+
+	k := MustParseRUT("6-k")
+	for _, tt := range []struct {
+		value driver.Valuer
+		want  string
+	}{
+		{k, "6-K"},
+		{DottedRUT{k}, "6-K"},
+		{CompactRUT{k}, "6K"},
+		{DottedRUT{rut}, "79.517.385-4"},
+	} {
+		if value, err := tt.value.Value(); err != nil || value != tt.want {
+			t.Errorf("Value() of %T = %v, %v, want %s", tt.value, value, err, tt.want)
+		}
+	}
+	if value, _ := (CompactRUT{}).Value(); value != nil {
+		t.Errorf("Value() = %v, want nil", value)
+	}
+	var scanned CompactRUT
+	if err := scanned.Scan("6K"); err != nil || scanned.RUT != k {
+		t.Errorf("Scan() = %+v, %v", scanned, err)
+	}
+}
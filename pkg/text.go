@@ -0,0 +1,50 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// accentFolding maps the accented letters that can appear in SII names and descriptions to their
+// unaccented form.
+var accentFolding = map[rune]string{
+	'Á': "A", 'À': "A", 'Â': "A", 'Ä': "A", 'Ã': "A", 'Å': "A",
+	'É': "E", 'È': "E", 'Ê': "E", 'Ë': "E",
+	'Í': "I", 'Ì': "I", 'Î': "I", 'Ï': "I",
+	'Ó': "O", 'Ò': "O", 'Ô': "O", 'Ö': "O", 'Õ': "O",
+	'Ú': "U", 'Ù': "U", 'Û': "U", 'Ü': "U",
+	'Ñ': "N", 'Ç': "C", 'Ý': "Y", 'Ÿ': "Y",
+	'Æ': "AE", 'Œ': "OE", 'ß': "SS",
+}
+
+// NormalizeText converts a text to upper case, removes the accents (Ñ becomes N),
+// drops dots and apostrophes, replaces the rest of the punctuation with spaces and
+// collapses the whitespace.
+func NormalizeText(text string) string {
+	var sb strings.Builder
+	sb.Grow(len(text))
+	pendingSpace := false
+	for _, r := range strings.ToUpper(text) {
+		var folded string
+		switch {
+		case accentFolding[r] != "":
+			folded = accentFolding[r]
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			folded = string(r)
+		case r == '&':
+			folded = "&"
+		case r == '.' || r == '\'' || r == '’':
+			// "S.A." -> "SA", "O'HIGGINS" -> "OHIGGINS"
+			continue
+		default:
+			pendingSpace = sb.Len() > 0
+			continue
+		}
+		if pendingSpace {
+			sb.WriteByte(' ')
+			pendingSpace = false
+		}
+		sb.WriteString(folded)
+	}
+	return sb.String()
+}
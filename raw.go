@@ -0,0 +1,55 @@
+package gosii
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+// RawResponse is the response of the SII to a lookup, kept as evidence when Opts.KeepRaw is set.
+type RawResponse struct {
+	// Rut is the RUT that was looked up.
+	Rut string `json:"rut"`
+	// Body is the HTML of the response, as received (before decoding its charset).
+	Body      []byte      `json:"body"`
+	Header    http.Header `json:"header"`
+	FetchedAt time.Time   `json:"fetched_at"`
+	// URL is the final URL of the response, after redirects.
+	URL string `json:"url"`
+}
+
+// ParseRawResponse parses a RawResponse again, e.g. one stored by an older lookup after
+// a fix to the parser. It honours the parsing options of opts, which may be nil, and
+// returns the same errors as GetNameByRUT. The result keeps raw.
+func ParseRawResponse(raw *RawResponse, opts *Opts) (*Citizen, error) {
+	if opts == nil {
+		opts = &Opts{}
+	}
+	ctz, err := parseRaw(raw, *opts)
+	if err != nil {
+		return nil, err
+	}
+	ctz.Raw = raw
+	return ctz, nil
+}
+
+// parseRaw turns the response of the SII into a Citizen.
+func parseRaw(raw *RawResponse, opts Opts) (*Citizen, error) {
+	html := decodeBody(raw.Body, raw.Header.Get("Content-Type"))
+	parser := opts.Parser
+	if parser == nil {
+		parser = DefaultParser()
+	}
+	ctz, err := parser.Parse(html)
+	if err != nil {
+		return nil, err
+	}
+	// the RUT was validated before the lookup; a stored raw response may lack it
+	ctz.Rut, _ = pkg.ParseRUT(raw.Rut)
+	ctz.Classify()
+	if opts.FillActivityNames {
+		fillActivityNames(ctz)
+	}
+	return ctz, nil
+}
@@ -0,0 +1,52 @@
+package gosii
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseRawResponse(t *testing.T) {
+	body, err := os.ReadFile("parsertest/testdata/company.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := &RawResponse{
+		Rut:       "79517385-4",
+		Body:      body,
+		Header:    http.Header{"Content-Type": {"text/html"}},
+		FetchedAt: time.Date(2026, 10, 18, 10, 15, 0, 0, time.UTC),
+		URL:       siiNameByRUTURL,
+	}
+	// the raw response survives a round trip through JSON, e.g. in a store
+	data, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored RawResponse
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatal(err)
+	}
+
+	ctz, err := ParseRawResponse(&stored, nil)
+	if err != nil {
+		t.Fatalf("ParseRawResponse() error = %v", err)
+	}
+	if ctz.Rut.String() != "79517385-4" || ctz.Name != "COMERCIAL PIÑA SPA" || ctz.Kind != KindCompany || ctz.Raw != &stored {
+		t.Errorf("ParseRawResponse() = %+v", ctz)
+	}
+	want := []CommercialActivity{
+		{Code: "479100", Name: "VENTA AL POR MENOR POR CORREO, POR INTERNET Y VIA TELEFONICA"},
+		{Code: "469000", Name: "VENTA AL POR MAYOR NO ESPECIALIZADA"},
+	}
+	if len(ctz.Activities) != len(want) {
+		t.Fatalf("Activities = %+v, want %+v", ctz.Activities, want)
+	}
+	for i := range want {
+		if ctz.Activities[i] != want[i] {
+			t.Errorf("Activities[%d] = %+v, want %+v", i, ctz.Activities[i], want[i])
+		}
+	}
+}
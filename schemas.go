@@ -1,5 +1,11 @@
 package gosii
 
+import (
+	"time"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
 type CaptchaResp struct {
 	TxtCaptcha string `json:"txtCaptcha"`
 }
@@ -9,9 +15,34 @@ type CommercialActivity struct {
 	Name string `json:"name"`
 }
 
+type Observation struct {
+	Text string     `json:"text"`
+	Date *time.Time `json:"date,omitempty"`
+}
+
 type Citizen struct {
-	Rut        string               `json:"rut"`
+	Rut        pkg.RUT              `json:"rut"`
 	Run        string               `json:"run"`
 	Name       string               `json:"name"`
+	Kind       CitizenKind          `json:"kind"`
+	LegalForm  LegalForm            `json:"legal_form,omitempty"`
+	PersonName *PersonName          `json:"person_name,omitempty"`
 	Activities []CommercialActivity `json:"activities"`
+	// Observations are the remarks of the SII on the taxpayer (e.g. pending anotaciones).
+	Observations []Observation `json:"observations,omitempty"`
+	// BusinessEnded is set when the taxpayer filed its término de giro (end of business),
+	// on EndDate if the SII reports it.
+	BusinessEnded bool       `json:"business_ended"`
+	EndDate       *time.Time `json:"end_date,omitempty"`
+	// Raw is the response of the SII, only set with Opts.KeepRaw.
+	Raw *RawResponse `json:"raw,omitempty"`
+}
+
+type DTEReceiver struct {
+	Rut              pkg.RUT   `json:"rut"`
+	Name             string    `json:"name"`
+	ResolutionNumber string    `json:"resolution_number"`
+	ResolutionDate   time.Time `json:"resolution_date"`
+	ExchangeEmail    string    `json:"exchange_email"`
+	URL              string    `json:"url,omitempty"`
 }
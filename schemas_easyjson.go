@@ -7,6 +7,8 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	http "net/http"
+	time "time"
 )
 
 // suppress unused package warning
@@ -17,7 +19,193 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson2189435aDecodeGithubComEitolGosii(in *jlexer.Lexer, out *CommercialActivity) {
+func easyjson2189435aDecodeGithubComEitolGosii(in *jlexer.Lexer, out *Observation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		case "date":
+			if in.IsNull() {
+				in.Skip()
+				out.Date = nil
+			} else {
+				if out.Date == nil {
+					out.Date = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Date).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2189435aEncodeGithubComEitolGosii(out *jwriter.Writer, in Observation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	if in.Date != nil {
+		const prefix string = ",\"date\":"
+		out.RawString(prefix)
+		out.Raw((*in.Date).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Observation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2189435aEncodeGithubComEitolGosii(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Observation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2189435aEncodeGithubComEitolGosii(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Observation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2189435aDecodeGithubComEitolGosii(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Observation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2189435aDecodeGithubComEitolGosii(l, v)
+}
+func easyjson2189435aDecodeGithubComEitolGosii1(in *jlexer.Lexer, out *DTEReceiver) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "rut":
+			(out.Rut).UnmarshalEasyJSON(in)
+		case "name":
+			out.Name = string(in.String())
+		case "resolution_number":
+			out.ResolutionNumber = string(in.String())
+		case "resolution_date":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ResolutionDate).UnmarshalJSON(data))
+			}
+		case "exchange_email":
+			out.ExchangeEmail = string(in.String())
+		case "url":
+			out.URL = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2189435aEncodeGithubComEitolGosii1(out *jwriter.Writer, in DTEReceiver) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"rut\":"
+		out.RawString(prefix[1:])
+		(in.Rut).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"resolution_number\":"
+		out.RawString(prefix)
+		out.String(string(in.ResolutionNumber))
+	}
+	{
+		const prefix string = ",\"resolution_date\":"
+		out.RawString(prefix)
+		out.Raw((in.ResolutionDate).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"exchange_email\":"
+		out.RawString(prefix)
+		out.String(string(in.ExchangeEmail))
+	}
+	if in.URL != "" {
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DTEReceiver) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2189435aEncodeGithubComEitolGosii1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DTEReceiver) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2189435aEncodeGithubComEitolGosii1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DTEReceiver) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2189435aDecodeGithubComEitolGosii1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DTEReceiver) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2189435aDecodeGithubComEitolGosii1(l, v)
+}
+func easyjson2189435aDecodeGithubComEitolGosii2(in *jlexer.Lexer, out *CommercialActivity) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -50,7 +238,7 @@ func easyjson2189435aDecodeGithubComEitolGosii(in *jlexer.Lexer, out *Commercial
 		in.Consumed()
 	}
 }
-func easyjson2189435aEncodeGithubComEitolGosii(out *jwriter.Writer, in CommercialActivity) {
+func easyjson2189435aEncodeGithubComEitolGosii2(out *jwriter.Writer, in CommercialActivity) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -70,27 +258,27 @@ func easyjson2189435aEncodeGithubComEitolGosii(out *jwriter.Writer, in Commercia
 // MarshalJSON supports json.Marshaler interface
 func (v CommercialActivity) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2189435aEncodeGithubComEitolGosii(&w, v)
+	easyjson2189435aEncodeGithubComEitolGosii2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CommercialActivity) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2189435aEncodeGithubComEitolGosii(w, v)
+	easyjson2189435aEncodeGithubComEitolGosii2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CommercialActivity) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2189435aDecodeGithubComEitolGosii(&r, v)
+	easyjson2189435aDecodeGithubComEitolGosii2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CommercialActivity) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2189435aDecodeGithubComEitolGosii(l, v)
+	easyjson2189435aDecodeGithubComEitolGosii2(l, v)
 }
-func easyjson2189435aDecodeGithubComEitolGosii1(in *jlexer.Lexer, out *Citizen) {
+func easyjson2189435aDecodeGithubComEitolGosii3(in *jlexer.Lexer, out *Citizen) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -110,11 +298,25 @@ func easyjson2189435aDecodeGithubComEitolGosii1(in *jlexer.Lexer, out *Citizen)
 		}
 		switch key {
 		case "rut":
-			out.Rut = string(in.String())
+			(out.Rut).UnmarshalEasyJSON(in)
 		case "run":
 			out.Run = string(in.String())
 		case "name":
 			out.Name = string(in.String())
+		case "kind":
+			out.Kind = CitizenKind(in.String())
+		case "legal_form":
+			out.LegalForm = LegalForm(in.String())
+		case "person_name":
+			if in.IsNull() {
+				in.Skip()
+				out.PersonName = nil
+			} else {
+				if out.PersonName == nil {
+					out.PersonName = new(PersonName)
+				}
+				easyjson2189435aDecodeGithubComEitolGosii4(in, out.PersonName)
+			}
 		case "activities":
 			if in.IsNull() {
 				in.Skip()
@@ -138,6 +340,53 @@ func easyjson2189435aDecodeGithubComEitolGosii1(in *jlexer.Lexer, out *Citizen)
 				}
 				in.Delim(']')
 			}
+		case "observations":
+			if in.IsNull() {
+				in.Skip()
+				out.Observations = nil
+			} else {
+				in.Delim('[')
+				if out.Observations == nil {
+					if !in.IsDelim(']') {
+						out.Observations = make([]Observation, 0, 2)
+					} else {
+						out.Observations = []Observation{}
+					}
+				} else {
+					out.Observations = (out.Observations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 Observation
+					(v2).UnmarshalEasyJSON(in)
+					out.Observations = append(out.Observations, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "business_ended":
+			out.BusinessEnded = bool(in.Bool())
+		case "end_date":
+			if in.IsNull() {
+				in.Skip()
+				out.EndDate = nil
+			} else {
+				if out.EndDate == nil {
+					out.EndDate = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.EndDate).UnmarshalJSON(data))
+				}
+			}
+		case "raw":
+			if in.IsNull() {
+				in.Skip()
+				out.Raw = nil
+			} else {
+				if out.Raw == nil {
+					out.Raw = new(RawResponse)
+				}
+				easyjson2189435aDecodeGithubComEitolGosii5(in, out.Raw)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -148,14 +397,14 @@ func easyjson2189435aDecodeGithubComEitolGosii1(in *jlexer.Lexer, out *Citizen)
 		in.Consumed()
 	}
 }
-func easyjson2189435aEncodeGithubComEitolGosii1(out *jwriter.Writer, in Citizen) {
+func easyjson2189435aEncodeGithubComEitolGosii3(out *jwriter.Writer, in Citizen) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
 		const prefix string = ",\"rut\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Rut))
+		(in.Rut).MarshalEasyJSON(out)
 	}
 	{
 		const prefix string = ",\"run\":"
@@ -167,6 +416,21 @@ func easyjson2189435aEncodeGithubComEitolGosii1(out *jwriter.Writer, in Citizen)
 		out.RawString(prefix)
 		out.String(string(in.Name))
 	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	if in.LegalForm != "" {
+		const prefix string = ",\"legal_form\":"
+		out.RawString(prefix)
+		out.String(string(in.LegalForm))
+	}
+	if in.PersonName != nil {
+		const prefix string = ",\"person_name\":"
+		out.RawString(prefix)
+		easyjson2189435aEncodeGithubComEitolGosii4(out, *in.PersonName)
+	}
 	{
 		const prefix string = ",\"activities\":"
 		out.RawString(prefix)
@@ -174,42 +438,266 @@ func easyjson2189435aEncodeGithubComEitolGosii1(out *jwriter.Writer, in Citizen)
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v2, v3 := range in.Activities {
-				if v2 > 0 {
+			for v3, v4 := range in.Activities {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				(v4).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Observations) != 0 {
+		const prefix string = ",\"observations\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Observations {
+				if v5 > 0 {
 					out.RawByte(',')
 				}
-				(v3).MarshalEasyJSON(out)
+				(v6).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"business_ended\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.BusinessEnded))
+	}
+	if in.EndDate != nil {
+		const prefix string = ",\"end_date\":"
+		out.RawString(prefix)
+		out.Raw((*in.EndDate).MarshalJSON())
+	}
+	if in.Raw != nil {
+		const prefix string = ",\"raw\":"
+		out.RawString(prefix)
+		easyjson2189435aEncodeGithubComEitolGosii5(out, *in.Raw)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Citizen) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2189435aEncodeGithubComEitolGosii1(&w, v)
+	easyjson2189435aEncodeGithubComEitolGosii3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Citizen) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2189435aEncodeGithubComEitolGosii1(w, v)
+	easyjson2189435aEncodeGithubComEitolGosii3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Citizen) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2189435aDecodeGithubComEitolGosii1(&r, v)
+	easyjson2189435aDecodeGithubComEitolGosii3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Citizen) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2189435aDecodeGithubComEitolGosii1(l, v)
+	easyjson2189435aDecodeGithubComEitolGosii3(l, v)
 }
-func easyjson2189435aDecodeGithubComEitolGosii2(in *jlexer.Lexer, out *CaptchaResp) {
+func easyjson2189435aDecodeGithubComEitolGosii5(in *jlexer.Lexer, out *RawResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "rut":
+			out.Rut = string(in.String())
+		case "body":
+			if in.IsNull() {
+				in.Skip()
+				out.Body = nil
+			} else {
+				out.Body = in.Bytes()
+			}
+		case "header":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Header = make(http.Header)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v8 []string
+					if in.IsNull() {
+						in.Skip()
+						v8 = nil
+					} else {
+						in.Delim('[')
+						if v8 == nil {
+							if !in.IsDelim(']') {
+								v8 = make([]string, 0, 4)
+							} else {
+								v8 = []string{}
+							}
+						} else {
+							v8 = (v8)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v9 string
+							v9 = string(in.String())
+							v8 = append(v8, v9)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Header)[key] = v8
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "fetched_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.FetchedAt).UnmarshalJSON(data))
+			}
+		case "url":
+			out.URL = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2189435aEncodeGithubComEitolGosii5(out *jwriter.Writer, in RawResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"rut\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Rut))
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Body)
+	}
+	{
+		const prefix string = ",\"header\":"
+		out.RawString(prefix)
+		if in.Header == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v12First := true
+			for v12Name, v12Value := range in.Header {
+				if v12First {
+					v12First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v12Name))
+				out.RawByte(':')
+				if v12Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v13, v14 := range v12Value {
+						if v13 > 0 {
+							out.RawByte(',')
+						}
+						out.String(string(v14))
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"fetched_at\":"
+		out.RawString(prefix)
+		out.Raw((in.FetchedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.URL))
+	}
+	out.RawByte('}')
+}
+func easyjson2189435aDecodeGithubComEitolGosii4(in *jlexer.Lexer, out *PersonName) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "given_names":
+			out.GivenNames = string(in.String())
+		case "paternal_surname":
+			out.PaternalSurname = string(in.String())
+		case "maternal_surname":
+			out.MaternalSurname = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2189435aEncodeGithubComEitolGosii4(out *jwriter.Writer, in PersonName) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"given_names\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.GivenNames))
+	}
+	{
+		const prefix string = ",\"paternal_surname\":"
+		out.RawString(prefix)
+		out.String(string(in.PaternalSurname))
+	}
+	{
+		const prefix string = ",\"maternal_surname\":"
+		out.RawString(prefix)
+		out.String(string(in.MaternalSurname))
+	}
+	out.RawByte('}')
+}
+func easyjson2189435aDecodeGithubComEitolGosii6(in *jlexer.Lexer, out *CaptchaResp) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -240,7 +728,7 @@ func easyjson2189435aDecodeGithubComEitolGosii2(in *jlexer.Lexer, out *CaptchaRe
 		in.Consumed()
 	}
 }
-func easyjson2189435aEncodeGithubComEitolGosii2(out *jwriter.Writer, in CaptchaResp) {
+func easyjson2189435aEncodeGithubComEitolGosii6(out *jwriter.Writer, in CaptchaResp) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -255,23 +743,23 @@ func easyjson2189435aEncodeGithubComEitolGosii2(out *jwriter.Writer, in CaptchaR
 // MarshalJSON supports json.Marshaler interface
 func (v CaptchaResp) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2189435aEncodeGithubComEitolGosii2(&w, v)
+	easyjson2189435aEncodeGithubComEitolGosii6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CaptchaResp) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2189435aEncodeGithubComEitolGosii2(w, v)
+	easyjson2189435aEncodeGithubComEitolGosii6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CaptchaResp) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2189435aDecodeGithubComEitolGosii2(&r, v)
+	easyjson2189435aDecodeGithubComEitolGosii6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CaptchaResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2189435aDecodeGithubComEitolGosii2(l, v)
+	easyjson2189435aDecodeGithubComEitolGosii6(l, v)
 }
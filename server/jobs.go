@@ -0,0 +1,452 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Eitol/gosii"
+)
+
+type JobState string
+
+const (
+	JobQueued   JobState = "queued"
+	JobRunning  JobState = "running"
+	JobDone     JobState = "done"
+	JobCanceled JobState = "canceled"
+	// JobFailed is the state of a job whose progress could not be saved (see Job.Error).
+	JobFailed JobState = "failed"
+)
+
+var (
+	ErrJobNotFound    = errors.New("job not found")
+	ErrJobNotFinished = errors.New("job not finished")
+	ErrJobFinished    = errors.New("job already finished")
+)
+
+// Job is the progress of a batch of lookups.
+type Job struct {
+	ID    string   `json:"id"`
+	State JobState `json:"state"`
+	// Total is the number of RUTs of the job, Processed the ones looked up so far and
+	// Failed the ones that ended with an error (including not found).
+	Total      int        `json:"total"`
+	Processed  int        `json:"processed"`
+	Failed     int        `json:"failed"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// Error is the reason of a failed job.
+	Error string `json:"error,omitempty"`
+}
+
+// Result is the lookup of one RUT of a job.
+type Result struct {
+	Rut     string         `json:"rut"`
+	Citizen *gosii.Citizen `json:"citizen,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type job struct {
+	mutex   sync.Mutex
+	Job     Job
+	ruts    []string
+	results []Result
+	// cancel stops the lookups while the job is running.
+	cancel context.CancelFunc
+}
+
+// jobRecord is the file of a job in Opts.JobsDir. The results are kept apart, one per line.
+type jobRecord struct {
+	Job
+	RUTs []string `json:"ruts"`
+}
+
+type createJobRequest struct {
+	RUTs []string `json:"ruts"`
+}
+
+// maxRUTBytes is the room given to each RUT of a job in the request body, including its
+// quotes and separators, to bound the body to Opts.MaxBatch RUTs.
+const maxRUTBytes = 64
+
+// handleJobs serves /v1/jobs.
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, int64(s.opts.MaxBatch)*maxRUTBytes+1024)
+	var req createJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeError(w, status, err)
+		return
+	}
+	if len(req.RUTs) == 0 || len(req.RUTs) > s.opts.MaxBatch {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("a job needs between 1 and %d ruts", s.opts.MaxBatch))
+		return
+	}
+	j, err := s.createJob(req.RUTs)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Location", "/v1/jobs/"+j.Job.ID)
+	writeJSON(w, http.StatusAccepted, j.snapshot())
+}
+
+// handleJob serves /v1/jobs/{id} and /v1/jobs/{id}/results.
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	id, sub, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v1/jobs/"), "/")
+	j := s.job(id)
+	if j == nil {
+		writeError(w, http.StatusNotFound, ErrJobNotFound)
+		return
+	}
+	switch {
+	case sub == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, j.snapshot())
+	case sub == "" && r.Method == http.MethodDelete:
+		if err := s.cancelJob(j); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, j.snapshot())
+	case sub == "results" && r.Method == http.MethodGet:
+		s.writeResults(w, r, j)
+	case sub == "" || sub == "results":
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	default:
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+func (s *Server) job(id string) *job {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.jobs[id]
+}
+
+func (s *Server) createJob(ruts []string) (*job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+	j := &job{
+		Job:  Job{ID: id, State: JobQueued, Total: len(ruts), CreatedAt: time.Now().UTC()},
+		ruts: ruts,
+	}
+	if err := s.saveJob(j); err != nil {
+		return nil, err
+	}
+	s.mutex.Lock()
+	s.jobs[id] = j
+	s.mutex.Unlock()
+	s.start(j)
+	return j, nil
+}
+
+func (s *Server) start(j *job) {
+	s.wg.Add(1)
+	go s.run(j)
+}
+
+// run looks up the RUTs of a job that are still without a result.
+func (s *Server) run(j *job) {
+	defer s.wg.Done()
+	select {
+	case s.workers <- struct{}{}:
+		defer func() { <-s.workers }()
+	case <-s.ctx.Done():
+		return
+	}
+
+	j.mutex.Lock()
+	if j.Job.State == JobCanceled {
+		j.mutex.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	j.cancel = cancel
+	j.Job.State = JobRunning
+	if err := s.saveJob(j); err != nil {
+		s.fail(j, err)
+		j.mutex.Unlock()
+		return
+	}
+	next := len(j.results)
+	j.mutex.Unlock()
+
+	ctx = gosii.WithPriority(ctx, gosii.PriorityBatch)
+	for _, rut := range j.ruts[next:] {
+		citizen, _, err := gosii.LookupContext(ctx, s.opts.Client, rut)
+		if ctx.Err() != nil {
+			// canceled, or the server was closed; the RUT is looked up again on resume.
+			break
+		}
+		result := Result{Rut: rut, Citizen: citizen}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		if err := s.addResult(j, result); err != nil {
+			j.mutex.Lock()
+			j.cancel = nil
+			s.fail(j, err)
+			j.mutex.Unlock()
+			return
+		}
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.cancel = nil
+	if j.Job.State == JobRunning && j.Job.Processed == j.Job.Total {
+		j.finish(JobDone)
+		if err := s.saveJob(j); err != nil {
+			s.fail(j, err)
+		}
+	}
+}
+
+// addResult keeps the result of a lookup, unless the job was canceled meanwhile.
+func (s *Server) addResult(j *job, result Result) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if j.Job.State != JobRunning {
+		return nil
+	}
+	if s.opts.JobsDir != "" {
+		line, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		f, err := os.OpenFile(s.resultsPath(j.Job.ID), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(append(line, '\n'))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	j.addResult(result)
+	return nil
+}
+
+// fail ends a job whose progress or end could not be saved, so that it does not stay running.
+// Saving the failure is only attempted: if it does not work either, the job is resumed on
+// the next start. The caller must hold the mutex of the job.
+func (s *Server) fail(j *job, err error) {
+	j.Job.Error = err.Error()
+	j.finish(JobFailed)
+	_ = s.saveJob(j)
+}
+
+func (s *Server) cancelJob(j *job) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	switch j.Job.State {
+	case JobDone, JobFailed:
+		return ErrJobFinished
+	case JobCanceled:
+		return nil
+	}
+	if j.cancel != nil {
+		j.cancel()
+	}
+	j.finish(JobCanceled)
+	return s.saveJob(j)
+}
+
+// writeResults writes the results of a finished (done, canceled or failed) job as NDJSON,
+// or as CSV with ?format=csv or "Accept: text/csv".
+func (s *Server) writeResults(w http.ResponseWriter, r *http.Request, j *job) {
+	j.mutex.Lock()
+	state := j.Job.State
+	results := append([]Result(nil), j.results...)
+	j.mutex.Unlock()
+	if state == JobQueued || state == JobRunning {
+		writeError(w, http.StatusConflict, ErrJobNotFinished)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "csv" || strings.Contains(r.Header.Get("Accept"), "text/csv") {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", j.Job.ID+".csv"))
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{"rut", "name", "kind", "activities", "error"})
+		for _, result := range results {
+			record := []string{result.Rut, "", "", "", result.Error}
+			if c := result.Citizen; c != nil {
+				codes := make([]string, len(c.Activities))
+				for i, a := range c.Activities {
+					codes[i] = a.Code
+				}
+				record[1], record[2], record[3] = c.Name, string(c.Kind), strings.Join(codes, " ")
+			}
+			_ = cw.Write(record)
+		}
+		cw.Flush()
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", j.Job.ID+".ndjson"))
+	enc := json.NewEncoder(w)
+	for _, result := range results {
+		_ = enc.Encode(result)
+	}
+}
+
+func (j *job) snapshot() Job {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.Job
+}
+
+// finish sets the final state of the job. The caller must hold the mutex.
+func (j *job) finish(state JobState) {
+	now := time.Now().UTC()
+	j.Job.State = state
+	j.Job.FinishedAt = &now
+}
+
+// addResult appends a result and updates the progress. The caller must hold the mutex.
+func (j *job) addResult(result Result) {
+	j.results = append(j.results, result)
+	j.Job.Processed++
+	if result.Error != "" {
+		j.Job.Failed++
+	}
+}
+
+func (s *Server) jobPath(id string) string {
+	return filepath.Join(s.opts.JobsDir, id+".json")
+}
+
+func (s *Server) resultsPath(id string) string {
+	return filepath.Join(s.opts.JobsDir, id+".ndjson")
+}
+
+// saveJob writes the job to opts.JobsDir. The caller must hold the mutex of the job,
+// if it is already shared.
+func (s *Server) saveJob(j *job) error {
+	if s.opts.JobsDir == "" {
+		return nil
+	}
+	data, err := json.Marshal(jobRecord{Job: j.Job, RUTs: j.ruts})
+	if err != nil {
+		return err
+	}
+	path := s.jobPath(j.Job.ID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadJobs loads the jobs of opts.JobsDir and resumes the unfinished ones, oldest first.
+func (s *Server) loadJobs() error {
+	if s.opts.JobsDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(s.opts.JobsDir, 0755); err != nil {
+		return err
+	}
+	paths, err := filepath.Glob(filepath.Join(s.opts.JobsDir, "*.json"))
+	if err != nil {
+		return err
+	}
+	var pending []*job
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var record jobRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		j := &job{Job: record.Job, ruts: record.RUTs}
+		j.Job.Processed, j.Job.Failed = 0, 0
+		if err := s.loadResults(j); err != nil {
+			return fmt.Errorf("%s: %w", s.resultsPath(j.Job.ID), err)
+		}
+		s.jobs[j.Job.ID] = j
+		if j.Job.State == JobQueued || j.Job.State == JobRunning {
+			pending = append(pending, j)
+		}
+	}
+	sort.Slice(pending, func(a, b int) bool { return pending[a].Job.CreatedAt.Before(pending[b].Job.CreatedAt) })
+	for _, j := range pending {
+		s.start(j)
+	}
+	return nil
+}
+
+// loadResults reads the results of a job. An incomplete last line, left by a crash in the
+// middle of a write, is discarded.
+func (s *Server) loadResults(j *job) error {
+	f, err := os.OpenFile(s.resultsPath(j.Job.ID), os.O_RDWR, 0644)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	reader := bufio.NewReader(f)
+	offset := int64(0)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				return f.Truncate(offset)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		offset += int64(len(line))
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var result Result
+		if err := json.Unmarshal(line, &result); err != nil {
+			return err
+		}
+		j.addResult(result)
+	}
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
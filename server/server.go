@@ -0,0 +1,143 @@
+// Package server exposes a gosii.Client over HTTP.
+//
+// Routes:
+//
+//	GET    /v1/taxpayers/{rut}       looks up a RUT right away
+//	POST   /v1/jobs                  queues a batch of RUTs, {"ruts": [...]}
+//	GET    /v1/jobs/{id}             returns the progress of a job
+//	DELETE /v1/jobs/{id}             cancels a job
+//	GET    /v1/jobs/{id}/results     downloads the results as NDJSON, or CSV with ?format=csv
+//
+// A job ends done, canceled, or failed when its progress cannot be saved to Opts.JobsDir
+// (see Job.Error); the results looked up until then can still be downloaded.
+//
+// The jobs are looked up in the background with gosii.PriorityBatch, while the direct lookups
+// use gosii.PriorityInteractive, so both share the gosii.Limiter of the client.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/pkg"
+)
+
+const defaultMaxBatch = 10000
+
+type Opts struct {
+	// Client used for the lookups. Defaults to gosii.NewSIIClient(nil). Give it an
+	// Opts.Limiter to bound the requests to the SII.
+	Client gosii.Client
+	// JobsDir is the directory where the jobs and their results are kept, so they survive
+	// a restart. When empty, the jobs are only kept in memory.
+	JobsDir string
+	// Workers is the number of jobs processed at the same time. Defaults to 1.
+	Workers int
+	// MaxBatch is the maximum number of RUTs of a job. Defaults to 10000.
+	MaxBatch int
+}
+
+// Server is an http.Handler that serves the lookups and the jobs.
+type Server struct {
+	opts    Opts
+	mux     *http.ServeMux
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers chan struct{}
+	wg      sync.WaitGroup
+	mutex   sync.Mutex
+	jobs    map[string]*job
+}
+
+// New creates a Server and resumes the unfinished jobs of opts.JobsDir.
+func New(opts *Opts) (*Server, error) {
+	if opts == nil {
+		opts = &Opts{}
+	}
+	o := *opts
+	if o.Client == nil {
+		o.Client = gosii.NewSIIClient(nil)
+	}
+	if o.Workers <= 0 {
+		o.Workers = 1
+	}
+	if o.MaxBatch <= 0 {
+		o.MaxBatch = defaultMaxBatch
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		opts:    o,
+		mux:     http.NewServeMux(),
+		ctx:     ctx,
+		cancel:  cancel,
+		workers: make(chan struct{}, o.Workers),
+		jobs:    map[string]*job{},
+	}
+	s.mux.HandleFunc("/v1/taxpayers/", s.handleLookup)
+	s.mux.HandleFunc("/v1/jobs", s.handleJobs)
+	s.mux.HandleFunc("/v1/jobs/", s.handleJob)
+	if err := s.loadJobs(); err != nil {
+		cancel()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Close stops the jobs in progress and waits for them. They are resumed by the next Server
+// with the same JobsDir.
+func (s *Server) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Server) handleLookup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	rut := strings.TrimPrefix(r.URL.Path, "/v1/taxpayers/")
+	ctx := gosii.WithPriority(r.Context(), gosii.PriorityInteractive)
+	citizen, meta, err := gosii.LookupContext(ctx, s.opts.Client, rut)
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Citizen  *gosii.Citizen         `json:"citizen"`
+		Metadata *gosii.RequestMetadata `json:"metadata,omitempty"`
+	}{citizen, meta})
+}
+
+// statusOf returns the HTTP status of an error of the client.
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, pkg.ErrInvalidRUT), errors.Is(err, pkg.ErrInvalidDV), errors.Is(err, gosii.ErrMissingPurpose):
+		return http.StatusBadRequest
+	case errors.Is(err, gosii.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
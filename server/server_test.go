@@ -0,0 +1,299 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/pkg"
+)
+
+// fakeClient knows a few taxpayers. When gate is set, each lookup waits for it.
+type fakeClient struct {
+	gate chan struct{}
+}
+
+var fakeNames = map[string]string{
+	"79517385-4": "COMERCIAL PINA SPA",
+	"41817975-9": "FUNDACION LOS ANDES",
+}
+
+func (f *fakeClient) GetNameByRUT(rut string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	return f.GetNameByRUTContext(context.Background(), rut)
+}
+
+func (f *fakeClient) GetNameByRUTContext(ctx context.Context, rut string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	if f.gate != nil {
+		select {
+		case <-f.gate:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+	parsed, err := pkg.ParseRUT(rut)
+	if err != nil {
+		return nil, nil, err
+	}
+	name, ok := fakeNames[parsed.String()]
+	if !ok {
+		return nil, nil, gosii.ErrNotFound
+	}
+	return &gosii.Citizen{Rut: parsed, Name: name, Activities: []gosii.CommercialActivity{{Code: "829900"}}},
+		&gosii.RequestMetadata{Source: gosii.SourceSII}, nil
+}
+
+func do(t *testing.T, s *Server, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return rec
+}
+
+func createJob(t *testing.T, s *Server, ruts ...string) Job {
+	t.Helper()
+	body, _ := json.Marshal(createJobRequest{RUTs: ruts})
+	rec := do(t, s, http.MethodPost, "/v1/jobs", string(body))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("POST /v1/jobs = %d %s", rec.Code, rec.Body)
+	}
+	var job Job
+	if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func getJob(t *testing.T, s *Server, id string) Job {
+	t.Helper()
+	rec := do(t, s, http.MethodGet, "/v1/jobs/"+id, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /v1/jobs/%s = %d %s", id, rec.Code, rec.Body)
+	}
+	var job Job
+	if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+// waitJob waits until the job satisfies done.
+func waitJob(t *testing.T, s *Server, id string, done func(Job) bool) Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job := getJob(t, s, id)
+		if done(job) {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job = %+v", job)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	s, err := New(&Opts{Client: &fakeClient{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	rec := do(t, s, http.MethodGet, "/v1/taxpayers/79.517.385-4", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "COMERCIAL PINA SPA") {
+		t.Errorf("GET /v1/taxpayers = %d %s", rec.Code, rec.Body)
+	}
+	for rut, status := range map[string]int{"41817975-K": http.StatusBadRequest, "41399675-9": http.StatusNotFound} {
+		if rec := do(t, s, http.MethodGet, "/v1/taxpayers/"+rut, ""); rec.Code != status {
+			t.Errorf("GET /v1/taxpayers/%s = %d, want %d", rut, rec.Code, status)
+		}
+	}
+}
+
+func TestJob(t *testing.T) {
+	s, err := New(&Opts{Client: &fakeClient{}, JobsDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	job := createJob(t, s, "79.517.385-4", "41399675-9", "41817975-9")
+	if rec := do(t, s, http.MethodGet, "/v1/jobs/"+job.ID+"/results", ""); job.State != JobDone && rec.Code != http.StatusConflict {
+		t.Errorf("GET results of an unfinished job = %d, want 409", rec.Code)
+	}
+	job = waitJob(t, s, job.ID, func(j Job) bool { return j.State == JobDone })
+	if job.Total != 3 || job.Processed != 3 || job.Failed != 1 || job.FinishedAt == nil {
+		t.Errorf("job = %+v", job)
+	}
+
+	rec := do(t, s, http.MethodGet, "/v1/jobs/"+job.ID+"/results", "")
+	var results []Result
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var result Result
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+	if len(results) != 3 || results[0].Citizen.Name != "COMERCIAL PINA SPA" || results[1].Error != gosii.ErrNotFound.Error() {
+		t.Errorf("NDJSON results = %+v", results)
+	}
+
+	rec = do(t, s, http.MethodGet, "/v1/jobs/"+job.ID+"/results?format=csv", "")
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil || len(records) != 4 || records[3][1] != "FUNDACION LOS ANDES" || records[3][3] != "829900" {
+		t.Errorf("CSV results = %v, %v", records, err)
+	}
+
+	if rec := do(t, s, http.MethodDelete, "/v1/jobs/"+job.ID, ""); rec.Code != http.StatusConflict {
+		t.Errorf("DELETE of a done job = %d, want 409", rec.Code)
+	}
+	if rec := do(t, s, http.MethodGet, "/v1/jobs/0000000000000000", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("GET of an unknown job = %d, want 404", rec.Code)
+	}
+	if rec := do(t, s, http.MethodPost, "/v1/jobs", `{"ruts": []}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("POST of an empty job = %d, want 400", rec.Code)
+	}
+}
+
+func TestJob_TooLarge(t *testing.T) {
+	s, err := New(&Opts{Client: &fakeClient{}, MaxBatch: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	body := `{"ruts": ["` + strings.Repeat("1", 1<<20) + `"]}`
+	if rec := do(t, s, http.MethodPost, "/v1/jobs", body); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("POST of a large body = %d, want 413", rec.Code)
+	}
+}
+
+func TestJob_Failed(t *testing.T) {
+	dir := t.TempDir()
+	client := &fakeClient{gate: make(chan struct{})}
+	s, err := New(&Opts{Client: client, JobsDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	job := createJob(t, s, "79.517.385-4", "41817975-9")
+	// the results can no longer be written
+	if err := os.Mkdir(filepath.Join(dir, job.ID+".ndjson"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	client.gate <- struct{}{}
+	job = waitJob(t, s, job.ID, func(j Job) bool { return j.State != JobQueued && j.State != JobRunning })
+	if job.State != JobFailed || job.Error == "" || job.Processed != 0 || job.FinishedAt == nil {
+		t.Errorf("job = %+v, want failed", job)
+	}
+	if rec := do(t, s, http.MethodGet, "/v1/jobs/"+job.ID+"/results", ""); rec.Code != http.StatusOK {
+		t.Errorf("GET results of a failed job = %d, want 200", rec.Code)
+	}
+
+	// a failed job is not resumed
+	if err := os.Remove(filepath.Join(dir, job.ID+".ndjson")); err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := New(&Opts{Client: &fakeClient{}, JobsDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restarted.Close()
+	if job := getJob(t, restarted, job.ID); job.State != JobFailed {
+		t.Errorf("job after restart = %+v, want failed", job)
+	}
+}
+
+func TestJob_FailedFinalSave(t *testing.T) {
+	dir := t.TempDir()
+	client := &fakeClient{gate: make(chan struct{})}
+	s, err := New(&Opts{Client: client, JobsDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	job := createJob(t, s, "79.517.385-4")
+	waitJob(t, s, job.ID, func(j Job) bool { return j.State == JobRunning })
+	// the job can no longer be saved, but its results can
+	if err := os.Mkdir(filepath.Join(dir, job.ID+".json.tmp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	client.gate <- struct{}{}
+	job = waitJob(t, s, job.ID, func(j Job) bool { return j.State != JobQueued && j.State != JobRunning })
+	if job.State != JobFailed || job.Error == "" || job.Processed != 1 {
+		t.Errorf("job = %+v, want failed", job)
+	}
+}
+
+func TestJob_Cancel(t *testing.T) {
+	client := &fakeClient{gate: make(chan struct{})}
+	s, err := New(&Opts{Client: client})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	job := createJob(t, s, "79.517.385-4", "41817975-9")
+	client.gate <- struct{}{}
+	waitJob(t, s, job.ID, func(j Job) bool { return j.Processed == 1 })
+
+	rec := do(t, s, http.MethodDelete, "/v1/jobs/"+job.ID, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("DELETE = %d %s", rec.Code, rec.Body)
+	}
+	job = getJob(t, s, job.ID)
+	if job.State != JobCanceled || job.Processed != 1 {
+		t.Errorf("job = %+v, want canceled after 1 lookup", job)
+	}
+	rec = do(t, s, http.MethodGet, "/v1/jobs/"+job.ID+"/results", "")
+	if rec.Code != http.StatusOK || strings.Count(rec.Body.String(), "\n") != 1 {
+		t.Errorf("results = %d %s, want the partial results", rec.Code, rec.Body)
+	}
+}
+
+func TestJob_Resume(t *testing.T) {
+	dir := t.TempDir()
+	client := &fakeClient{gate: make(chan struct{})}
+	s, err := New(&Opts{Client: client, JobsDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := createJob(t, s, "79.517.385-4", "41817975-9", "41399675-9")
+	client.gate <- struct{}{}
+	waitJob(t, s, job.ID, func(j Job) bool { return j.Processed == 1 })
+	_ = s.Close()
+
+	// a crash in the middle of a write leaves an incomplete line
+	f, err := os.OpenFile(filepath.Join(dir, job.ID+".ndjson"), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"rut":"38179`)
+	_ = f.Close()
+
+	s, err = New(&Opts{Client: &fakeClient{}, JobsDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	job = waitJob(t, s, job.ID, func(j Job) bool { return j.State == JobDone })
+	if job.Processed != 3 || job.Failed != 1 {
+		t.Errorf("job = %+v", job)
+	}
+	rec := do(t, s, http.MethodGet, "/v1/jobs/"+job.ID+"/results?format=csv", "")
+	if records, err := csv.NewReader(rec.Body).ReadAll(); err != nil || len(records) != 4 || records[2][0] != "41817975-9" {
+		t.Errorf("CSV results = %v, %v", records, err)
+	}
+}
@@ -1,6 +1,7 @@
 package gosii
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"io"
@@ -12,38 +13,80 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/PuerkitoBio/goquery"
+	"github.com/Eitol/gosii/activities"
+	"github.com/Eitol/gosii/pkg"
 )
 
 const (
 	xpathRazonSocial = "html body div div:nth-child(4)"
 	xpathActivities  = "html body div table tr"
+	xpathTables      = "html body div table"
 
 	siiNameByRUTURL = "https://zeus.sii.cl/cvc_cgi/stc/getstc"
+
+	// SourceSII is the RequestMetadata.Source of the results fetched from the SII.
+	SourceSII = "sii"
 )
 
 var ErrNotFound = errors.New("not found")
 var ErrCaptcha = errors.New("not found")
 
-type siiHTTPClient struct {
+// SIIClient is the Client that looks up the taxpayers in the SII website. Create it with NewSIIClient.
+type SIIClient struct {
 	captcha      *Captcha
 	captchaMutex sync.Mutex
 	opts         Opts
 	httpClient   *http.Client
 	requestCount atomic.Uint64
+	// refreshing has the RUTs being refreshed in the background (see Opts.Cache).
+	refreshing sync.Map
 }
 
 type Opts struct {
 	OnNewCaptcha func(captcha *Captcha)
+	// Audit records every lookup (see AuditSink).
+	Audit AuditSink
+	// RequirePurpose rejects the lookups without a purpose in their context
+	// (see WithAuditInfo) with ErrMissingPurpose. Only used with Audit.
+	RequirePurpose bool
+	// FillActivityNames fills the name of the activities that come without it
+	// using the activities catalog.
+	FillActivityNames bool
+	// KeepRaw attaches the raw response of the SII to the Citizen (see Citizen.Raw),
+	// e.g. to keep it as evidence or to parse it again with ParseRawResponse.
+	KeepRaw bool
+	// Parser parses the pages of the SII. Defaults to DefaultParser().
+	Parser ResponseParser
+	// Cache keeps the citizens fetched, to answer without waiting for the SII (see Cache).
+	Cache Cache
+	// CacheTTL is how long a cached citizen is fresh. Defaults to 1 hour.
+	CacheTTL time.Duration
+	// StaleWhileRevalidate is how long after CacheTTL a stale citizen is returned right away
+	// while it is fetched again in the background. Defaults to 1 hour.
+	StaleWhileRevalidate time.Duration
+	// MaxStale is how long after CacheTTL a stale citizen is returned when the SII is
+	// unavailable. Defaults to 24 hours.
+	MaxStale time.Duration
+	// Limiter bounds the lookups to the SII, starting the queued ones by their priority
+	// (see WithPriority). The cache hits do not wait for it.
+	Limiter *Limiter
+	// Verify sets the thresholds of VerifyName. May be nil.
+	Verify *VerifyOpts
 }
 
 func NewClient(opts *Opts) Client {
+	return NewSIIClient(opts)
+}
+
+// NewSIIClient is NewClient for the callers that need the methods of the SII client beyond
+// the Client interface, such as GetNameByRUTContext, SelfCheck or VerifyName.
+func NewSIIClient(opts *Opts) *SIIClient {
 	httpClient := buildHTTPClient()
 
 	if opts == nil {
 		opts = &Opts{}
 	}
-	return &siiHTTPClient{opts: *opts, httpClient: httpClient}
+	return &SIIClient{opts: *opts, httpClient: httpClient}
 }
 
 // GetNameByRUT fetches the name of a citizen from the Servicio de Impuestos Internos (SII)
@@ -60,21 +103,95 @@ func NewClient(opts *Opts) Client {
 // and the commercial activities associated with the citizen, or an error if the request fails
 // or the RUT is not found.
 //
-// Response example: Citizen{Name:"MIGUEL JUAN SEBASTIAN PINERA ECHENIQUE", Activities:[]string{"829900"}}
+// Response example: Citizen{Name:"JOSE MIGUEL PEREZ NUNEZ", Activities:[]string{"829900"}}
 //
-// Returns sii.ErrNotFound if the RUT is not found.
+// Returns sii.ErrNotFound if the RUT is not found, and pkg.ErrInvalidRUT or pkg.ErrInvalidDV
+// if the RUT is malformed or its check digit is wrong (no request is made in that case).
 //
 // Please note that this method relies on the structure of SII's service and its response.
-// If the service URL or the response structure changes, this method may not work as expected.
-func (c *siiHTTPClient) GetNameByRUT(rut string) (*Citizen, *RequestMetadata, error) {
-	captcha, err := c.assertCaptcha()
+// If the service URL or the response structure changes, this method may not work as expected:
+// when the landmarks of the page are missing it returns a *LayoutError, which matches
+// ErrUnexpectedLayout (see also SelfCheck).
+func (c *SIIClient) GetNameByRUT(rut string) (*Citizen, *RequestMetadata, error) {
+	return c.GetNameByRUTContext(context.Background(), rut)
+}
+
+// GetNameByRUTContext is like GetNameByRUT, but the requests to the SII are bound to ctx.
+//
+// When Opts.Audit is set, the lookup is recorded in the audit log with the purpose and user
+// of ctx (see WithAuditInfo). If the audit log cannot be written, the result is discarded
+// and an error wrapping ErrAudit is returned.
+//
+// When Opts.Cache is set, the citizen may come from the cache, possibly stale: see
+// RequestMetadata.Source, Stale and Age.
+//
+// When Opts.Limiter is set, the lookup waits for a slot with the priority of ctx (see
+// WithPriority and GetNameByRUTPriority) and the wait is reported in RequestMetadata.QueueWait,
+// also when the lookup fails.
+func (c *SIIClient) GetNameByRUTContext(ctx context.Context, rut string) (*Citizen, *RequestMetadata, error) {
+	info, hasInfo := AuditInfoFromContext(ctx)
+	if c.opts.Audit != nil && c.opts.RequirePurpose && (!hasInfo || info.Purpose == "") {
+		return nil, &RequestMetadata{}, ErrMissingPurpose
+	}
+	if c.opts.Cache != nil {
+		return c.cachedLookup(ctx, info, rut)
+	}
+	return c.fetch(ctx, info, rut)
+}
+
+// GetNameByRUTPriority is like GetNameByRUTContext, with the priority of the lookup in
+// Opts.Limiter given for this call instead of through ctx.
+func (c *SIIClient) GetNameByRUTPriority(ctx context.Context, rut string, priority Priority) (*Citizen, *RequestMetadata, error) {
+	return c.GetNameByRUTContext(WithPriority(ctx, priority), rut)
+}
+
+// fetch looks up a RUT in the SII and records it in the audit log. The metadata is returned
+// even on error, with the time spent in the queue of Opts.Limiter.
+func (c *SIIClient) fetch(ctx context.Context, info AuditInfo, rut string) (*Citizen, *RequestMetadata, error) {
+	var citizen *Citizen
+	var meta *RequestMetadata
+	var raw *RawResponse
+	var queueWait time.Duration
+	// a malformed RUT does not wait for the limiter, since no request is made
+	_, err := pkg.ParseRUT(rut)
+	if err == nil && c.opts.Limiter != nil {
+		var release func()
+		release, queueWait, err = c.opts.Limiter.Acquire(ctx, PriorityFromContext(ctx))
+		if err == nil {
+			defer release()
+		}
+	}
+	if err == nil {
+		citizen, meta, raw, err = c.lookup(ctx, rut)
+	}
+	if meta == nil {
+		meta = &RequestMetadata{}
+	}
+	meta.QueueWait = queueWait
+	if c.opts.Audit != nil {
+		var body []byte
+		if raw != nil {
+			body = raw.Body
+		}
+		if auditErr := c.audit(info, rut, body, err); auditErr != nil {
+			return nil, meta, auditErr
+		}
+	}
+	return citizen, meta, err
+}
+
+func (c *SIIClient) lookup(ctx context.Context, rut string) (*Citizen, *RequestMetadata, *RawResponse, error) {
+	if _, err := pkg.ParseRUT(rut); err != nil {
+		return nil, nil, nil, err
+	}
+	captcha, err := c.assertCaptcha(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	citizen, meta, err := c.getUserByRUTAndCaptcha(rut, *captcha)
+	citizen, meta, raw, err := c.getUserByRUTAndCaptcha(ctx, rut, *captcha)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			return nil, nil, ErrNotFound
+			return nil, nil, raw, ErrNotFound
 		}
 		if errors.Is(err, ErrCaptcha) {
 			c.captchaMutex.Lock()
@@ -82,17 +199,17 @@ func (c *siiHTTPClient) GetNameByRUT(rut string) (*Citizen, *RequestMetadata, er
 				c.captcha = nil
 			}
 			c.captchaMutex.Unlock()
-			return c.GetNameByRUT(rut)
+			return c.lookup(ctx, rut)
 		}
 	}
-	return citizen, &meta, err
+	return citizen, &meta, raw, err
 }
 
-func (c *siiHTTPClient) assertCaptcha() (*Captcha, error) {
+func (c *SIIClient) assertCaptcha(ctx context.Context) (*Captcha, error) {
 	c.captchaMutex.Lock()
 	defer c.captchaMutex.Unlock()
 	if c.captcha == nil {
-		newCaptcha, err := c.fetchCaptcha()
+		newCaptcha, err := c.fetchCaptcha(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -108,16 +225,17 @@ func (c *siiHTTPClient) assertCaptcha() (*Captcha, error) {
 }
 
 // fetchCaptcha fetches a captcha from the SII's service.
-func (c *siiHTTPClient) getUserByRUTAndCaptcha(rut string, captcha Captcha) (*Citizen, RequestMetadata, error) {
-	attempts := 3
+func (c *SIIClient) getUserByRUTAndCaptcha(ctx context.Context, rut string, captcha Captcha) (*Citizen, RequestMetadata, *RawResponse, error) {
+	maxAttempts := attemptsFromContext(ctx)
+	attempts := maxAttempts
 	var err error
-	var body []byte
+	var raw *RawResponse
 	var requestTimes []time.Duration
 	for attempts > 0 {
 		// time btwn 0 and 8 seconds
 		awaitSecondsTime := time.Duration(rand.Intn(8)) * time.Second
 		var req *http.Request
-		req, err = c.buildRequest(rut, captcha)
+		req, err = c.buildRequest(ctx, rut, captcha)
 		if err != nil {
 			break
 		}
@@ -129,14 +247,28 @@ func (c *siiHTTPClient) getUserByRUTAndCaptcha(rut string, captcha Captcha) (*Ci
 		requestTimes = append(requestTimes, endTime)
 		if err != nil {
 			attempts--
-			time.Sleep(awaitSecondsTime)
+			if sleepErr := retryWait(ctx, attempts, awaitSecondsTime); sleepErr != nil {
+				err = sleepErr
+				break
+			}
 			continue
 		}
+		var body []byte
 		body, err = io.ReadAll(res.Body)
 		_ = res.Body.Close()
+		raw = &RawResponse{
+			Rut:       rut,
+			Body:      body,
+			Header:    res.Header,
+			FetchedAt: time.Now(),
+			URL:       res.Request.URL.String(),
+		}
 		if err != nil {
 			attempts--
-			time.Sleep(awaitSecondsTime)
+			if sleepErr := retryWait(ctx, attempts, awaitSecondsTime); sleepErr != nil {
+				err = sleepErr
+				break
+			}
 			continue
 		}
 		break
@@ -149,74 +281,91 @@ func (c *siiHTTPClient) getUserByRUTAndCaptcha(rut string, captcha Captcha) (*Ci
 	meta := RequestMetadata{
 		TotalCount: int(c.requestCount.Load()),
 		AvgTime:    avgTime,
-		Attempts:   3 - attempts,
+		Attempts:   maxAttempts - attempts,
+		Source:     SourceSII,
+	}
+	if c.opts.Parser != nil {
+		meta.ParserVersion = c.opts.Parser.Version()
+	} else {
+		meta.ParserVersion = DefaultParser().Version()
 	}
 	if err != nil {
-		return nil, meta, err
+		return nil, meta, nil, err
 	}
-	ctz, err := c.parseSIIHTMLResponse(string(body))
+	ctz, err := parseRaw(raw, c.opts)
 	if err != nil {
-		if strings.Contains(string(body), "**") {
-			return nil, meta, ErrNotFound
+		return nil, meta, raw, err
+	}
+	if c.opts.KeepRaw {
+		ctz.Raw = raw
+	}
+	return ctz, meta, raw, nil
+}
+
+// fillActivityNames sets the name of the activities that come without it
+// from the activities catalog.
+func fillActivityNames(ctz *Citizen) {
+	for i, activity := range ctz.Activities {
+		if activity.Name != "" {
+			continue
+		}
+		if a, ok := activities.Lookup(activity.Code); ok {
+			ctz.Activities[i].Name = a.Description
 		}
-		return nil, meta, err
 	}
-	ctz.Rut = rut
-	return ctz, meta, nil
 }
 
-func (c *siiHTTPClient) buildRequest(rut string, captcha Captcha) (*http.Request, error) {
-	rut = strings.ReplaceAll(rut, ".", "")
-	rut = strings.ReplaceAll(rut, "-", "")
-	run := rut[:len(rut)-1]
-	dv := strings.ToUpper(rut[len(rut)-1:])
+func (c *SIIClient) buildRequest(ctx context.Context, rut string, captcha Captcha) (*http.Request, error) {
+	parsed, err := pkg.ParseRUT(rut)
+	if err != nil {
+		return nil, err
+	}
 	url := siiNameByRUTURL
 	method := "POST"
-	payloadStr := "RUT=" + run +
-		"&DV=" + dv +
+	payloadStr := "RUT=" + strconv.Itoa(parsed.Number) +
+		"&DV=" + parsed.DV +
 		"&txt_captcha=" + captcha.Text +
 		"&txt_code=" + captcha.Solution +
 		"&PRG=STC" +
 		"&OPC=NOR"
 	payload := strings.NewReader(payloadStr)
-	return http.NewRequest(method, url, payload)
+	return http.NewRequestWithContext(ctx, method, url, payload)
 }
 
-func (c *siiHTTPClient) parseSIIHTMLResponse(html string) (*Citizen, error) {
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
-	if err != nil {
-		return nil, err
+// sleepContext waits for d or until ctx is done.
+// retryWait waits d before another attempt, unless none is left.
+func retryWait(ctx context.Context, attemptsLeft int, d time.Duration) error {
+	if attemptsLeft == 0 {
+		return nil
 	}
+	return sleepContext(ctx, d)
+}
 
-	if strings.Contains(html, "Por favor reingrese Captcha") {
-		return nil, ErrCaptcha
-	}
+// defaultAttempts is the number of times that a captcha or a lookup is tried.
+const defaultAttempts = 3
 
-	razonSocial := strings.TrimSpace(doc.Find(xpathRazonSocial).Text())
-	if razonSocial == "" || razonSocial == "**" {
-		return nil, ErrNotFound
-	}
-	var actividades []CommercialActivity
+type singleAttemptKey struct{}
 
-	doc.Find(xpathActivities).Each(func(i int, s *goquery.Selection) {
-		if i > 0 {
-			codigo := s.Find("td:nth-child(2) font").Text()
-			var codeInt int
-			codeInt, err = strconv.Atoi(codigo)
-			if err != nil {
-				return
-			}
-			if codeInt > 1970 && codeInt <= time.Now().Year() {
-				return
-			}
-			actividades = append(actividades, CommercialActivity{
-				Code: codigo,
-			})
-		}
-	})
+// withSingleAttempt returns a copy of ctx whose lookups try the SII only once, for the
+// callers that have something to fall back to (see cachedLookup).
+func withSingleAttempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, singleAttemptKey{}, true)
+}
 
-	return &Citizen{
-		Name:       razonSocial,
-		Activities: actividades,
-	}, nil
+func attemptsFromContext(ctx context.Context) int {
+	if single, _ := ctx.Value(singleAttemptKey{}).(bool); single {
+		return 1
+	}
+	return defaultAttempts
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
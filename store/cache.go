@@ -0,0 +1,33 @@
+package store
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Eitol/gosii"
+)
+
+type storeCache struct {
+	store Store
+}
+
+// AsCache returns a gosii.Cache backed by a Store, so that the citizens cached by the
+// client (see gosii.Opts.Cache) survive restarts and are kept in the history.
+func AsCache(s Store) gosii.Cache {
+	return &storeCache{store: s}
+}
+
+func (c *storeCache) Get(rut string) (*gosii.Citizen, time.Time, error) {
+	record, err := c.store.Get(rut)
+	if errors.Is(err, ErrNotFound) {
+		return nil, time.Time{}, gosii.ErrNotFound
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return record.Citizen, record.Time, nil
+}
+
+func (c *storeCache) Set(rut string, citizen *gosii.Citizen, fetchedAt time.Time) error {
+	return c.store.Put(Record{Rut: rut, Time: fetchedAt, Citizen: citizen})
+}
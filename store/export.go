@@ -0,0 +1,79 @@
+package store
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+type ExportOpts struct {
+	// Pseudonymizer replaces the RUTs with their pseudonyms. When nil, the RUTs are masked
+	// (see pkg.RUT.Mask).
+	Pseudonymizer *pkg.Pseudonymizer
+	// History exports every record instead of the latest one of each RUT.
+	History bool
+}
+
+type exportedRecord struct {
+	Rut     string                     `json:"rut"`
+	Time    time.Time                  `json:"time"`
+	Citizen map[string]json.RawMessage `json:"citizen"`
+}
+
+// Export writes the records of a store to w as JSON lines for analytics, without the RUTs:
+// the RUT of each record and the rut and run of its citizen are pseudonymized or masked, and
+// the raw response of the SII, which contains the RUT, is left out. opts may be nil.
+func Export(s Store, w io.Writer, opts *ExportOpts) error {
+	if opts == nil {
+		opts = &ExportOpts{}
+	}
+	hide := func(rut string) string {
+		parsed, err := pkg.ParseRUT(rut)
+		switch {
+		case err != nil:
+			return ""
+		case opts.Pseudonymizer != nil:
+			return opts.Pseudonymizer.Pseudonym(parsed)
+		default:
+			return parsed.Mask()
+		}
+	}
+	encoder := json.NewEncoder(w)
+	write := func(record Record) error {
+		exported := exportedRecord{Rut: hide(record.Rut), Time: record.Time}
+		if record.Citizen != nil {
+			data, err := json.Marshal(record.Citizen)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(data, &exported.Citizen); err != nil {
+				return err
+			}
+			delete(exported.Citizen, "raw")
+			for _, field := range []string{"rut", "run"} {
+				var value string
+				_ = json.Unmarshal(exported.Citizen[field], &value)
+				hidden, _ := json.Marshal(hide(value))
+				exported.Citizen[field] = hidden
+			}
+		}
+		return encoder.Encode(exported)
+	}
+	return s.Iterate(func(latest Record) error {
+		if !opts.History {
+			return write(latest)
+		}
+		records, err := s.History(latest.Rut)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if err := write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,192 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// location is the position of a record in the file.
+type location struct {
+	offset int64
+	length int
+}
+
+// FileStore is a Store backed by a single append-only file with one JSON record per line.
+// The file is indexed in memory when opened, and records are read from disk on demand.
+type FileStore struct {
+	mutex   sync.RWMutex
+	f       *os.File
+	size    int64
+	index   map[string][]location
+	numbers map[string]int
+	// Sync calls fsync after each Put. It is slower but survives power failures.
+	Sync bool
+}
+
+// OpenFile opens (or creates) a FileStore at path. An incomplete last line, left by a
+// crash in the middle of a Put, is discarded.
+func OpenFile(path string) (*FileStore, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s := &FileStore{f: f, index: map[string][]location{}, numbers: map[string]int{}}
+	if err := s.load(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileStore) load() error {
+	reader := bufio.NewReader(s.f)
+	offset := int64(0)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// discard the incomplete last line, if any
+			if len(line) > 0 {
+				if err := s.f.Truncate(offset); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			offset += int64(len(line))
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			return err
+		}
+		if err := s.addToIndex(record.Rut, location{offset: offset, length: len(line)}); err != nil {
+			return err
+		}
+		offset += int64(len(line))
+	}
+	s.size = offset
+	return nil
+}
+
+func (s *FileStore) addToIndex(rut string, loc location) error {
+	k, number, err := key(rut)
+	if err != nil {
+		return err
+	}
+	s.index[k] = append(s.index[k], loc)
+	s.numbers[k] = number
+	return nil
+}
+
+func (s *FileStore) Put(record Record) error {
+	k, _, err := key(record.Rut)
+	if err != nil {
+		return err
+	}
+	record.Rut = k
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.f.WriteAt(line, s.size); err != nil {
+		return err
+	}
+	if s.Sync {
+		if err := s.f.Sync(); err != nil {
+			return err
+		}
+	}
+	loc := location{offset: s.size, length: len(line)}
+	s.size += int64(len(line))
+	return s.addToIndex(k, loc)
+}
+
+func (s *FileStore) Get(rut string) (*Record, error) {
+	k, _, err := key(rut)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	locs := s.index[k]
+	if len(locs) == 0 {
+		return nil, ErrNotFound
+	}
+	return s.read(locs[len(locs)-1])
+}
+
+func (s *FileStore) History(rut string) ([]Record, error) {
+	k, _, err := key(rut)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	locs := s.index[k]
+	if len(locs) == 0 {
+		return nil, ErrNotFound
+	}
+	records := make([]Record, 0, len(locs))
+	for _, loc := range locs {
+		record, err := s.read(loc)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+	return records, nil
+}
+
+func (s *FileStore) Iterate(fn func(record Record) error) error {
+	s.mutex.RLock()
+	keys := sortedKeys(s.numbers)
+	s.mutex.RUnlock()
+	for _, k := range keys {
+		record, err := s.Get(k)
+		if err != nil {
+			return err
+		}
+		if err := fn(*record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.f.Close()
+}
+
+// read reads the record at loc. The caller must hold the mutex.
+func (s *FileStore) read(loc location) (*Record, error) {
+	line := make([]byte, loc.length)
+	if _, err := s.f.ReadAt(line, loc.offset); err != nil {
+		return nil, err
+	}
+	var record Record
+	if err := json.Unmarshal(bytes.TrimSpace(line), &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
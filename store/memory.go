@@ -0,0 +1,76 @@
+package store
+
+import "sync"
+
+// MemoryStore is a Store that keeps the records in memory, e.g. for tests.
+type MemoryStore struct {
+	mutex   sync.RWMutex
+	records map[string][]Record
+	numbers map[string]int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: map[string][]Record{}, numbers: map[string]int{}}
+}
+
+func (s *MemoryStore) Put(record Record) error {
+	k, number, err := key(record.Rut)
+	if err != nil {
+		return err
+	}
+	record.Rut = k
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.records[k] = append(s.records[k], record)
+	s.numbers[k] = number
+	return nil
+}
+
+func (s *MemoryStore) Get(rut string) (*Record, error) {
+	k, _, err := key(rut)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	records := s.records[k]
+	if len(records) == 0 {
+		return nil, ErrNotFound
+	}
+	record := records[len(records)-1]
+	return &record, nil
+}
+
+func (s *MemoryStore) History(rut string) ([]Record, error) {
+	k, _, err := key(rut)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	records := s.records[k]
+	if len(records) == 0 {
+		return nil, ErrNotFound
+	}
+	return append([]Record(nil), records...), nil
+}
+
+func (s *MemoryStore) Iterate(fn func(record Record) error) error {
+	s.mutex.RLock()
+	keys := sortedKeys(s.numbers)
+	s.mutex.RUnlock()
+	for _, k := range keys {
+		record, err := s.Get(k)
+		if err != nil {
+			return err
+		}
+		if err := fn(*record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
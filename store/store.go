@@ -0,0 +1,53 @@
+// Package store keeps the history of the lookups of each RUT.
+package store
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/pkg"
+)
+
+var ErrNotFound = errors.New("rut not found in store")
+
+// Record is the result of a lookup at a given time.
+type Record struct {
+	Rut     string         `json:"rut"`
+	Time    time.Time      `json:"time"`
+	Citizen *gosii.Citizen `json:"citizen"`
+}
+
+// Store keeps every Record put by RUT. Implementations are safe for concurrent use.
+type Store interface {
+	// Put adds a record to the history of its RUT.
+	Put(record Record) error
+	// Get returns the latest record of a RUT, or ErrNotFound.
+	Get(rut string) (*Record, error)
+	// History returns every record of a RUT, oldest first, or ErrNotFound.
+	History(rut string) ([]Record, error)
+	// Iterate calls fn with the latest record of each RUT, sorted by RUT number,
+	// until fn returns an error.
+	Iterate(fn func(record Record) error) error
+	Close() error
+}
+
+// key returns the canonical form of a RUT ("12345678-9") used to index the records.
+func key(rut string) (string, int, error) {
+	parsed, err := pkg.ParseRUT(rut)
+	if err != nil {
+		return "", 0, err
+	}
+	return parsed.String(), parsed.Number, nil
+}
+
+// sortedKeys returns the keys of numbers sorted by RUT number.
+func sortedKeys(numbers map[string]int) []string {
+	keys := make([]string, 0, len(numbers))
+	for k := range numbers {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return numbers[keys[i]] < numbers[keys[j]] })
+	return keys
+}
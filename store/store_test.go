@@ -0,0 +1,152 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/pkg"
+)
+
+func testStore(t *testing.T, s Store) {
+	t.Helper()
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []Record{
+		{Rut: "79.517.385-4", Time: t0, Citizen: &gosii.Citizen{Name: "COMERCIAL PINA SPA"}},
+		{Rut: "41399675-9", Time: t0, Citizen: &gosii.Citizen{Name: "ANA ROJAS"}},
+		{Rut: "79517385-4", Time: t0.Add(time.Hour), Citizen: &gosii.Citizen{Name: "COMERCIAL PIÑA SPA"}},
+	}
+	for _, r := range records {
+		if err := s.Put(r); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+	latest, err := s.Get("795173854")
+	if err != nil || latest.Citizen.Name != "COMERCIAL PIÑA SPA" || !latest.Time.Equal(t0.Add(time.Hour)) {
+		t.Errorf("Get() = %+v, %v", latest, err)
+	}
+	history, err := s.History("79517385-4")
+	if err != nil || len(history) != 2 || history[0].Citizen.Name != "COMERCIAL PINA SPA" {
+		t.Errorf("History() = %+v, %v", history, err)
+	}
+	if _, err := s.Get("42533552-9"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+	var ruts []string
+	err = s.Iterate(func(r Record) error {
+		ruts = append(ruts, r.Rut)
+		return nil
+	})
+	if err != nil || len(ruts) != 2 || ruts[0] != "41399675-9" || ruts[1] != "79517385-4" {
+		t.Errorf("Iterate() = %v, %v", ruts, err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lookups.jsonl")
+	s, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, s)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a crash in the middle of a Put
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"rut":"41399675-9","ti`)
+	_ = f.Close()
+
+	reopened, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	defer reopened.Close()
+	history, err := reopened.History("79517385-4")
+	if err != nil || len(history) != 2 {
+		t.Errorf("History() after reopen = %+v, %v", history, err)
+	}
+	if err := reopened.Put(Record{Rut: "41399675-9", Citizen: &gosii.Citizen{Name: "ANA ROJAS SOTO"}}); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := reopened.Get("41399675-9")
+	if err != nil || latest.Citizen.Name != "ANA ROJAS SOTO" {
+		t.Errorf("Get() after reopen = %+v, %v", latest, err)
+	}
+}
+
+func TestExport(t *testing.T) {
+	rut := pkg.MustParseRUT("79517385-4")
+	s := NewMemoryStore()
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, name := range []string{"COMERCIAL PINA SPA", "COMERCIAL PIÑA SPA"} {
+		citizen := &gosii.Citizen{Rut: rut, Run: "79517385-4", Name: name, Raw: &gosii.RawResponse{Rut: rut.String()}}
+		if err := s.Put(Record{Rut: rut.String(), Time: t0.Add(time.Duration(i) * time.Hour), Citizen: citizen}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var masked bytes.Buffer
+	if err := Export(s, &masked, nil); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if strings.Count(masked.String(), "\n") != 1 || strings.Count(masked.String(), `"**.***.385-*"`) != 3 ||
+		strings.Contains(masked.String(), "79517385") || strings.Contains(masked.String(), `"raw"`) {
+		t.Errorf("Export() = %s", masked.String())
+	}
+
+	p := pkg.NewPseudonymizer([]byte("secret"))
+	var pseudonymized bytes.Buffer
+	if err := Export(s, &pseudonymized, &ExportOpts{Pseudonymizer: p, History: true}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(pseudonymized.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Export() = %s, want 2 records", pseudonymized.String())
+	}
+	var exported struct {
+		Rut     string `json:"rut"`
+		Citizen struct {
+			Rut  string `json:"rut"`
+			Name string `json:"name"`
+		} `json:"citizen"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &exported); err != nil {
+		t.Fatal(err)
+	}
+	if exported.Rut != p.Pseudonym(rut) || exported.Citizen.Rut != exported.Rut || exported.Citizen.Name != "COMERCIAL PIÑA SPA" {
+		t.Errorf("Export() = %s", lines[1])
+	}
+	if back, ok := p.Reverse(exported.Rut); !ok || back != rut {
+		t.Errorf("Reverse() = %v, %v", back, ok)
+	}
+}
+
+func TestAsCache(t *testing.T) {
+	cache := AsCache(NewMemoryStore())
+	if _, _, err := cache.Get("79517385-4"); !errors.Is(err, gosii.ErrNotFound) {
+		t.Errorf("Get() error = %v, want gosii.ErrNotFound", err)
+	}
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := cache.Set("79517385-4", &gosii.Citizen{Name: "COMERCIAL PIÑA SPA"}, t0); err != nil {
+		t.Fatal(err)
+	}
+	citizen, fetchedAt, err := cache.Get("79.517.385-4")
+	if err != nil || citizen.Name != "COMERCIAL PIÑA SPA" || !fetchedAt.Equal(t0) {
+		t.Errorf("Get() = %+v, %v, %v", citizen, fetchedAt, err)
+	}
+}
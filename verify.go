@@ -0,0 +1,226 @@
+package gosii
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultMatchThreshold = 0.9
+	defaultWordThreshold  = 0.8
+	// legalFormPenalty is subtracted from the score when both names have different legal forms.
+	legalFormPenalty = 0.15
+)
+
+type VerifyOpts struct {
+	// MatchThreshold is the minimum score for NameMatch.Match. Defaults to 0.9.
+	MatchThreshold float64
+	// WordThreshold is the minimum similarity for two words to be considered the same
+	// word with a typo (e.g. "GONZALES" and "GONZALEZ"). Defaults to 0.8.
+	WordThreshold float64
+	// Kind of the taxpayer. The surname particles are only ignored for natural persons;
+	// when empty (or KindUnknown), names without a legal form are taken as persons.
+	// VerifyName sets it to the kind of the citizen found.
+	Kind CitizenKind
+}
+
+// NameMatch is the result of matching a claimed name against the name known by the SII.
+type NameMatch struct {
+	Rut          string `json:"rut,omitempty"`
+	ClaimedName  string `json:"claimed_name"`
+	OfficialName string `json:"official_name"`
+	// Score goes from 0 (nothing in common) to 1 (same name).
+	Score float64 `json:"score"`
+	Match bool    `json:"match"`
+	// Reasons explain the differences found, e.g. "word order differs".
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// VerifyName looks up a RUT and tells whether it belongs to claimedName (see MatchName),
+// with the thresholds of Opts.Verify.
+//
+//	m, err := client.VerifyName(ctx, "79.517.385-4", "Comercial Piña SpA")
+//	if err == nil && m.Match { ... }
+func (c *SIIClient) VerifyName(ctx context.Context, rut, claimedName string) (*NameMatch, error) {
+	ctz, _, err := c.GetNameByRUTContext(ctx, rut)
+	if err != nil {
+		return nil, err
+	}
+	var opts VerifyOpts
+	if c.opts.Verify != nil {
+		opts = *c.opts.Verify
+	}
+	opts.Kind = ctz.Kind
+	m := MatchName(ctz.Name, claimedName, &opts)
+	m.Rut = rut
+	return &m, nil
+}
+
+// MatchName compares a claimed name with the official one. Names are compared word by word
+// after NormalizeName, so case, accents and punctuation are ignored; the order of the words
+// does not matter (surnames first or last), the surname particles of persons ("DE", "DEL",
+// ...) are ignored (see VerifyOpts.Kind) and words with small typos still match. The legal
+// forms of companies are compared apart, so "COMERCIAL PIÑA SPA" matches "Comercial Piña
+// Sociedad por Acciones" and "Comercial Piña", while "Comercial Piña Ltda." is penalized.
+// opts may be nil.
+func MatchName(officialName, claimedName string, opts *VerifyOpts) NameMatch {
+	o := VerifyOpts{MatchThreshold: defaultMatchThreshold, WordThreshold: defaultWordThreshold}
+	if opts != nil {
+		if opts.MatchThreshold > 0 {
+			o.MatchThreshold = opts.MatchThreshold
+		}
+		if opts.WordThreshold > 0 {
+			o.WordThreshold = opts.WordThreshold
+		}
+		o.Kind = opts.Kind
+	}
+	m := NameMatch{ClaimedName: claimedName, OfficialName: officialName}
+	officialForm, claimedForm := DetectLegalForm(officialName), DetectLegalForm(claimedName)
+	person := o.Kind == KindPerson
+	if o.Kind == "" || o.Kind == KindUnknown {
+		person = officialForm == "" && claimedForm == ""
+	}
+	official := nameWords(officialName, officialForm, person)
+	claimed := nameWords(claimedName, claimedForm, person)
+	if len(official) == 0 || len(claimed) == 0 {
+		m.Reasons = append(m.Reasons, "empty name")
+		return m
+	}
+
+	matched := 0.0
+	used := make([]bool, len(official))
+	var order []int
+	var extra []string
+	for _, c := range claimed {
+		best, bestSimilarity := -1, 0.0
+		for i, w := range official {
+			if used[i] {
+				continue
+			}
+			if s := wordSimilarity(c, w); s > bestSimilarity {
+				best, bestSimilarity = i, s
+			}
+		}
+		if best < 0 || bestSimilarity < o.WordThreshold {
+			extra = append(extra, c)
+			continue
+		}
+		used[best] = true
+		order = append(order, best)
+		matched += bestSimilarity
+		if bestSimilarity < 1 {
+			m.Reasons = append(m.Reasons, fmt.Sprintf("typo: %s ~ %s", c, official[best]))
+		}
+	}
+	var missing []string
+	for i, w := range official {
+		if !used[i] {
+			missing = append(missing, w)
+		}
+	}
+	m.Score = 2 * matched / float64(len(official)+len(claimed))
+
+	if NormalizeName(officialName) == NormalizeName(claimedName) && officialName != claimedName {
+		m.Reasons = append(m.Reasons, "case, accents or punctuation differ")
+	}
+	if !sort.IntsAreSorted(order) {
+		m.Reasons = append(m.Reasons, "word order differs")
+	}
+	if len(missing) > 0 {
+		m.Reasons = append(m.Reasons, "missing words: "+strings.Join(missing, " "))
+	}
+	if len(extra) > 0 {
+		m.Reasons = append(m.Reasons, "unknown words: "+strings.Join(extra, " "))
+	}
+	switch {
+	case officialForm != "" && claimedForm != "" && officialForm != claimedForm:
+		m.Score -= legalFormPenalty
+		m.Reasons = append(m.Reasons, fmt.Sprintf("legal form differs: %s != %s", officialForm, claimedForm))
+	case officialForm != claimedForm:
+		m.Reasons = append(m.Reasons, "legal form omitted")
+	}
+	if m.Score < 0 {
+		m.Score = 0
+	}
+	m.Match = m.Score >= o.MatchThreshold
+	return m
+}
+
+// nameWords returns the normalized words of a name without its legal form (if form is not
+// empty) and, for persons, without the surname particles.
+func nameWords(name string, form LegalForm, person bool) []string {
+	tokens := strings.Fields(NormalizeName(name))
+	if form != "" {
+		tokens = stripLegalForm(tokens)
+	}
+	if !person {
+		return tokens
+	}
+	words := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if !surnameParticles[t] {
+			words = append(words, t)
+		}
+	}
+	return words
+}
+
+// stripLegalForm removes the tokens of the legal form found by DetectLegalForm.
+func stripLegalForm(tokens []string) []string {
+	normalized := strings.Join(tokens, " ")
+	for _, kw := range legalFormKeywords {
+		if strings.Contains(normalized, kw.keyword) {
+			return strings.Fields(strings.Replace(normalized, kw.keyword, " ", 1))
+		}
+	}
+	for _, suffix := range legalFormSuffixes {
+		if len(tokens) > len(suffix.tokens) && hasSuffixTokens(tokens, suffix.tokens) {
+			return tokens[:len(tokens)-len(suffix.tokens)]
+		}
+	}
+	if _, ok := legalFormPrefixes[tokens[0]]; ok {
+		return tokens[1:]
+	}
+	return tokens
+}
+
+// wordSimilarity returns 1 minus the edit distance of the words relative to the longest one.
+func wordSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j] + 1
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+			if previous[j-1]+cost < current[j] {
+				current[j] = previous[j-1] + cost
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
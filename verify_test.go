@@ -0,0 +1,79 @@
+package gosii
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Eitol/gosii/pkg"
+)
+
+func TestMatchName(t *testing.T) {
+	tests := []struct {
+		name      string
+		official  string
+		claimed   string
+		wantMatch bool
+		reason    string
+	}{
+		{"same", "COMERCIAL PIÑA SPA", "COMERCIAL PIÑA SPA", true, ""},
+		{"accents and case", "COMERCIAL PIÑA SPA", "Comercial Pina SpA", true, "case, accents or punctuation differ"},
+		{"legal form spelled out", "COMERCIAL PIÑA SPA", "Comercial Piña Sociedad por Acciones", true, ""},
+		{"legal form omitted", "COMERCIAL PIÑA SPA", "Comercial Piña", true, "legal form omitted"},
+		{"other legal form", "COMERCIAL PIÑA SPA", "Comercial Piña Ltda.", false, "legal form differs"},
+		{"surnames first", "ANA MARIA ROJAS DEL CAMPO", "Rojas del Campo, Ana María", true, "word order differs"},
+		{"typo", "JUAN GONZALEZ SOTO", "JUAN GONZALES SOTO", true, "typo: GONZALES ~ GONZALEZ"},
+		{"missing given name", "ANA MARIA ROJAS CAMPO", "ANA ROJAS CAMPO", false, "missing words: MARIA"},
+		{"other person", "ANA MARIA ROJAS CAMPO", "PEDRO PEREZ SOTO", false, "unknown words"},
+		{"empty", "ANA ROJAS", "", false, "empty name"},
+		{"company particles", "INMOBILIARIA SANTA ROSA SPA", "INMOBILIARIA ROSA SPA", false, "missing words: SANTA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := MatchName(tt.official, tt.claimed, nil)
+			if m.Match != tt.wantMatch {
+				t.Errorf("MatchName() = %+v, want match %v", m, tt.wantMatch)
+			}
+			if tt.reason != "" && !strings.Contains(strings.Join(m.Reasons, "\n"), tt.reason) {
+				t.Errorf("MatchName() reasons = %q, want %q", m.Reasons, tt.reason)
+			}
+		})
+	}
+}
+
+func TestMatchName_Thresholds(t *testing.T) {
+	m := MatchName("ANA MARIA ROJAS CAMPO", "ANA ROJAS CAMPO", &VerifyOpts{MatchThreshold: 0.8})
+	if !m.Match {
+		t.Errorf("MatchName() = %+v, want match", m)
+	}
+	m = MatchName("JUAN GONZALEZ SOTO", "JUAN GONZALES SOTO", &VerifyOpts{WordThreshold: 0.95})
+	if m.Match {
+		t.Errorf("MatchName() = %+v, want no match", m)
+	}
+}
+
+func TestMatchName_Kind(t *testing.T) {
+	// a person with a legal form in the name, e.g. "DE LA FUENTE EIRL"
+	if m := MatchName("JUAN DE LA FUENTE", "JUAN FUENTE", &VerifyOpts{Kind: KindPerson}); !m.Match {
+		t.Errorf("MatchName() of a person = %+v, want match", m)
+	}
+	if m := MatchName("COMERCIAL DE LA FUENTE", "COMERCIAL FUENTE", &VerifyOpts{Kind: KindCompany}); m.Match {
+		t.Errorf("MatchName() of a company = %+v, want no match", m)
+	}
+}
+
+func TestVerifyName(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(&Opts{Verify: &VerifyOpts{MatchThreshold: 0.95}}, newFakeSII("COMERCIAL PI\xd1A SPA"))
+	m, err := client.VerifyName(ctx, "79517385-4", "comercial piña")
+	if err != nil || !m.Match || m.Rut != "79517385-4" || m.OfficialName != "COMERCIAL PIÑA SPA" {
+		t.Errorf("VerifyName() = %+v, %v", m, err)
+	}
+	if m, err := client.VerifyName(ctx, "79517385-4", "comercial pina ltda"); err != nil || m.Match {
+		t.Errorf("VerifyName() with another legal form = %+v, %v, want no match", m, err)
+	}
+	if _, err := client.VerifyName(ctx, "79517385-5", "X"); !errors.Is(err, pkg.ErrInvalidDV) {
+		t.Errorf("VerifyName() error = %v, want ErrInvalidDV", err)
+	}
+}
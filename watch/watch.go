@@ -0,0 +1,267 @@
+// Package watch keeps a watchlist of RUTs, looks them up again periodically and reports
+// the changes (see gosii.Diff) of each taxpayer.
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Eitol/gosii"
+	"github.com/Eitol/gosii/pkg"
+)
+
+const (
+	defaultInterval = 24 * time.Hour
+	defaultDelay    = 2 * time.Second
+	webhookTimeout  = 10 * time.Second
+)
+
+var ErrWebhook = errors.New("webhook failed")
+
+// Event is emitted when a watched taxpayer changes.
+type Event struct {
+	Rut  string            `json:"rut"`
+	Time time.Time         `json:"time"`
+	Old  *gosii.Citizen    `json:"old"`
+	New  *gosii.Citizen    `json:"new"`
+	Diff gosii.CitizenDiff `json:"diff"`
+}
+
+type Opts struct {
+	// Client used for the lookups. Defaults to gosii.NewSIIClient(nil).
+	Client gosii.Client
+	// Interval between two checks of the whole watchlist. Defaults to 24 hours.
+	Interval time.Duration
+	// Delay between two lookups, to keep the pace of the requests to the SII.
+	// Defaults to 2 seconds.
+	Delay time.Duration
+	// StatePath is the JSON file where the last known citizens are kept between runs.
+	// When empty, the state is only kept in memory.
+	StatePath string
+	// OnChange is called for each change.
+	OnChange func(event Event)
+	// WebhookURL receives a POST with each Event as JSON.
+	WebhookURL string
+	// OnError is called when a lookup or the webhook fails.
+	OnError func(rut string, err error)
+}
+
+// Watcher keeps the last known citizen of each watched RUT.
+type Watcher struct {
+	opts       Opts
+	httpClient *http.Client
+	mutex      sync.Mutex
+	// last known citizen by RUT; nil until the first successful lookup.
+	state map[string]*gosii.Citizen
+}
+
+// New creates a Watcher, loading the state from opts.StatePath if it exists.
+func New(opts *Opts) (*Watcher, error) {
+	if opts == nil {
+		opts = &Opts{}
+	}
+	o := *opts
+	if o.Client == nil {
+		o.Client = gosii.NewSIIClient(nil)
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultInterval
+	}
+	if o.Delay <= 0 {
+		o.Delay = defaultDelay
+	}
+	w := &Watcher{
+		opts:       o,
+		httpClient: &http.Client{Timeout: webhookTimeout},
+		state:      map[string]*gosii.Citizen{},
+	}
+	if o.StatePath != "" {
+		data, err := os.ReadFile(o.StatePath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &w.state); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return w, nil
+}
+
+// Add adds a RUT to the watchlist. The first lookup only records the citizen.
+func (w *Watcher) Add(rut string) error {
+	key, err := rutKey(rut)
+	if err != nil {
+		return err
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if _, ok := w.state[key]; !ok {
+		w.state[key] = nil
+	}
+	return w.saveState()
+}
+
+// Remove removes a RUT from the watchlist.
+func (w *Watcher) Remove(rut string) error {
+	key, err := rutKey(rut)
+	if err != nil {
+		return err
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	delete(w.state, key)
+	return w.saveState()
+}
+
+// RUTs returns the watched RUTs, sorted.
+func (w *Watcher) RUTs() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	ruts := make([]string, 0, len(w.state))
+	for rut := range w.state {
+		ruts = append(ruts, rut)
+	}
+	sort.Strings(ruts)
+	return ruts
+}
+
+// Last returns the last known citizen of a watched RUT.
+func (w *Watcher) Last(rut string) (*gosii.Citizen, bool) {
+	key, err := rutKey(rut)
+	if err != nil {
+		return nil, false
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	ctz, ok := w.state[key]
+	return ctz, ok && ctz != nil
+}
+
+// Run checks the watchlist every opts.Interval until ctx is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if err := w.CheckAll(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll looks up every watched RUT, waiting opts.Delay between lookups.
+func (w *Watcher) CheckAll(ctx context.Context) error {
+	for i, rut := range w.RUTs() {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(w.opts.Delay):
+			}
+		}
+		if _, err := w.Check(ctx, rut); err != nil && w.opts.OnError != nil {
+			w.opts.OnError(rut, err)
+		}
+	}
+	return nil
+}
+
+// Check looks up a watched RUT and returns the event if it changed.
+func (w *Watcher) Check(ctx context.Context, rut string) (*Event, error) {
+	key, err := rutKey(rut)
+	if err != nil {
+		return nil, err
+	}
+	current, _, err := gosii.LookupContext(ctx, w.opts.Client, key)
+	if err != nil {
+		return nil, err
+	}
+	w.mutex.Lock()
+	previous, watched := w.state[key]
+	if !watched {
+		w.mutex.Unlock()
+		return nil, nil
+	}
+	w.state[key] = current
+	err = w.saveState()
+	w.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return nil, nil
+	}
+	diff := gosii.Diff(previous, current)
+	if diff.Empty() {
+		return nil, nil
+	}
+	event := &Event{Rut: key, Time: time.Now(), Old: previous, New: current, Diff: diff}
+	w.emit(*event)
+	return event, nil
+}
+
+func (w *Watcher) emit(event Event) {
+	if w.opts.OnChange != nil {
+		w.opts.OnChange(event)
+	}
+	if w.opts.WebhookURL == "" {
+		return
+	}
+	if err := w.postWebhook(event); err != nil && w.opts.OnError != nil {
+		w.opts.OnError(event.Rut, err)
+	}
+}
+
+func (w *Watcher) postWebhook(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	res, err := w.httpClient.Post(w.opts.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Join(ErrWebhook, err)
+	}
+	_ = res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%w: %s", ErrWebhook, res.Status)
+	}
+	return nil
+}
+
+// saveState writes the state to opts.StatePath. The caller must hold the mutex.
+func (w *Watcher) saveState() error {
+	if w.opts.StatePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(w.state)
+	if err != nil {
+		return err
+	}
+	tmp := w.opts.StatePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, w.opts.StatePath)
+}
+
+func rutKey(rut string) (string, error) {
+	parsed, err := pkg.ParseRUT(rut)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
@@ -0,0 +1,95 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Eitol/gosii"
+)
+
+type sequenceClient struct {
+	citizens []*gosii.Citizen
+}
+
+func (c *sequenceClient) GetNameByRUT(rut string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	return c.GetNameByRUTContext(context.Background(), rut)
+}
+
+func (c *sequenceClient) GetNameByRUTContext(context.Context, string) (*gosii.Citizen, *gosii.RequestMetadata, error) {
+	ctz := c.citizens[0]
+	if len(c.citizens) > 1 {
+		c.citizens = c.citizens[1:]
+	}
+	return ctz, &gosii.RequestMetadata{}, nil
+}
+
+func TestWatcher(t *testing.T) {
+	webhookEvents := make(chan Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("webhook body: %v", err)
+		}
+		webhookEvents <- event
+	}))
+	defer server.Close()
+
+	client := &sequenceClient{citizens: []*gosii.Citizen{
+		{Name: "COMERCIAL PINA SPA", Activities: []gosii.CommercialActivity{{Code: "471100"}}},
+		{Name: "COMERCIAL PINA SPA", Activities: []gosii.CommercialActivity{{Code: "471100"}}},
+		{Name: "COMERCIAL PIÑA SPA", Activities: []gosii.CommercialActivity{{Code: "479100"}}},
+	}}
+	var changes []Event
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	w, err := New(&Opts{
+		Client:     client,
+		Delay:      time.Millisecond,
+		StatePath:  statePath,
+		OnChange:   func(e Event) { changes = append(changes, e) },
+		WebhookURL: server.URL,
+		OnError:    func(rut string, err error) { t.Errorf("OnError(%s) = %v", rut, err) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Add("79.517.385-4"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := w.CheckAll(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want 1", len(changes))
+	}
+	d := changes[0].Diff
+	if !d.NameChanged() || d.NewName != "COMERCIAL PIÑA SPA" ||
+		len(d.AddedActivities) != 1 || d.AddedActivities[0].Code != "479100" ||
+		len(d.RemovedActivities) != 1 || d.RemovedActivities[0].Code != "471100" {
+		t.Errorf("diff = %+v", d)
+	}
+	select {
+	case event := <-webhookEvents:
+		if event.Rut != "79517385-4" {
+			t.Errorf("webhook event = %+v", event)
+		}
+	default:
+		t.Errorf("the webhook was not called")
+	}
+
+	// the state survives a restart
+	restarted, err := New(&Opts{Client: client, StatePath: statePath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	last, ok := restarted.Last("79517385-4")
+	if !ok || last.Name != "COMERCIAL PIÑA SPA" {
+		t.Errorf("Last() = %+v, %v", last, ok)
+	}
+}